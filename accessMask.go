@@ -0,0 +1,182 @@
+package eveapi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Page creating an XML API key with a predefined access mask.
+const xmlKeyCreationURL = "https://community.eveonline.com/support/api-key/CreatePredefined"
+
+// AccessMask is the access mask of an XML API key, one bit per call.
+type AccessMask int64
+
+// XMLCallGroup is a group of calls on the key creation page.
+type XMLCallGroup struct {
+	GroupID     int64
+	Name        string
+	Description string
+}
+
+// XMLCall is a private XML API call and the access mask bit that allows it.
+type XMLCall struct {
+	AccessMask  int64
+	Type        XMLKeyType
+	Name        string
+	GroupID     int64
+	Description string
+}
+
+// CallList is the catalogue of private XML API calls.
+type CallList struct {
+	Groups []XMLCallGroup
+	Calls  []XMLCall
+}
+
+// CallListXML returned data from XML API
+type CallListXML struct {
+	xmlAPIFrame
+	Rowsets []struct {
+		Name string `xml:"name,attr"`
+		Rows []struct {
+			AccessMask  int64      `xml:"accessMask,attr"`
+			Type        XMLKeyType `xml:"type,attr"`
+			Name        string     `xml:"name,attr"`
+			GroupID     int64      `xml:"groupID,attr"`
+			Description string     `xml:"description,attr"`
+		} `xml:"row"`
+	} `xml:"result>rowset"`
+}
+
+// CallListXML queries the XML API for the catalogue of calls and access masks.
+func (c *EVEAPIClient) CallListXML() (*CallListXML, error) {
+	w := &CallListXML{}
+	url := c.base.XML + "api/CallList.xml.aspx"
+
+	_, err := c.doXML("GET", url, nil, w, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CallList returns the groups and calls of the response.
+func (l *CallListXML) CallList() *CallList {
+	list := &CallList{}
+	for _, rowset := range l.Rowsets {
+		for _, r := range rowset.Rows {
+			switch rowset.Name {
+			case "callGroups":
+				list.Groups = append(list.Groups, XMLCallGroup{r.GroupID, r.Name, r.Description})
+			case "calls":
+				list.Calls = append(list.Calls, XMLCall{r.AccessMask, r.Type, r.Name, r.GroupID, r.Description})
+			}
+		}
+	}
+	return list
+}
+
+// ParseCallList reads a saved api/CallList.xml.aspx response.
+func ParseCallList(r io.Reader) (*CallList, error) {
+	w := &CallListXML{}
+	if err := xml.NewDecoder(r).Decode(w); err != nil {
+		return nil, err
+	}
+	return w.CallList(), nil
+}
+
+var (
+	defaultCallList     *CallList
+	defaultCallListOnce sync.Once
+)
+
+// DefaultCallList returns the call list bundled with the package.
+func DefaultCallList() *CallList {
+	defaultCallListOnce.Do(func() {
+		w := &CallListXML{}
+		if err := xml.Unmarshal([]byte(callListFixture), w); err != nil {
+			panic(err)
+		}
+		defaultCallList = w.CallList()
+	})
+	return defaultCallList
+}
+
+// Account keys use the character calls.
+func callType(t XMLKeyType) XMLKeyType {
+	if t == XMLKeyAccount {
+		return XMLKeyCharacter
+	}
+	return t
+}
+
+// Allows reports whether the mask allows the named call for keys of type t. Calls
+// listed more than once, such as CharacterInfo, are allowed by any of their bits.
+func (l *CallList) Allows(m AccessMask, name string, t XMLKeyType) bool {
+	t = callType(t)
+	for _, c := range l.Calls {
+		if c.Type == t && c.Name == name && int64(m)&c.AccessMask != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedCalls returns the calls of type t allowed by the mask, ordered by mask bit.
+func (l *CallList) AllowedCalls(m AccessMask, t XMLKeyType) []XMLCall {
+	t = callType(t)
+	var calls []XMLCall
+	for _, c := range l.Calls {
+		if c.Type == t && int64(m)&c.AccessMask != 0 {
+			calls = append(calls, c)
+		}
+	}
+	sort.Slice(calls, func(i, j int) bool { return calls[i].AccessMask < calls[j].AccessMask })
+	return calls
+}
+
+// RequiredMask returns the mask allowing all the named calls for keys of type t.
+// Calls listed more than once need all of their bits.
+func (l *CallList) RequiredMask(t XMLKeyType, names ...string) (AccessMask, error) {
+	t = callType(t)
+	var m AccessMask
+	for _, name := range names {
+		found := false
+		for _, c := range l.Calls {
+			if c.Type == t && c.Name == name {
+				m |= AccessMask(c.AccessMask)
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown %s call %s", t, name)
+		}
+	}
+	return m, nil
+}
+
+// Allows reports whether the mask allows the named call using the bundled call list.
+func (m AccessMask) Allows(name string, t XMLKeyType) bool {
+	return DefaultCallList().Allows(m, name, t)
+}
+
+// AllowedCalls returns the calls allowed by the mask using the bundled call list.
+func (m AccessMask) AllowedCalls(t XMLKeyType) []XMLCall {
+	return DefaultCallList().AllowedCalls(m, t)
+}
+
+// RequiredMask returns the mask allowing all the named calls using the bundled call list.
+func RequiredMask(t XMLKeyType, names ...string) (AccessMask, error) {
+	return DefaultCallList().RequiredMask(t, names...)
+}
+
+// KeyCreationURL returns the link creating a key of type t with the mask.
+func (m AccessMask) KeyCreationURL(t XMLKeyType) string {
+	if t == XMLKeyCorporation {
+		return fmt.Sprintf("%s?accessMask=%d&type=Corporation", xmlKeyCreationURL, m)
+	}
+	return fmt.Sprintf("%s?accessMask=%d", xmlKeyCreationURL, m)
+}
@@ -0,0 +1,42 @@
+package eveapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAccessMask(t *testing.T) {
+	list := DefaultCallList()
+	if len(list.Groups) != 7 || len(list.Calls) == 0 {
+		t.Fatalf("Bundled call list not parsed %d groups %d calls", len(list.Groups), len(list.Calls))
+	}
+
+	m, err := RequiredMask(XMLKeyCharacter, "WalletJournal", "WalletTransactions", "AssetList")
+	if err != nil || m != 2097152|4194304|2 {
+		t.Errorf("Wrong required mask %d %v", m, err)
+	}
+	if !m.Allows("WalletJournal", XMLKeyAccount) || m.Allows("MarketOrders", XMLKeyCharacter) {
+		t.Errorf("Mask %d allows the wrong calls", m)
+	}
+	// Corporation bits differ from character bits.
+	if m.Allows("WalletJournal", XMLKeyCorporation) {
+		t.Errorf("Character mask allows corporation journal")
+	}
+	if calls := m.AllowedCalls(XMLKeyCharacter); len(calls) != 3 || calls[0].Name != "AssetList" {
+		t.Errorf("Wrong calls %v", calls)
+	}
+
+	if _, err := RequiredMask(XMLKeyCorporation, "SkillQueue"); err == nil {
+		t.Errorf("Corporation skill queue should be unknown")
+	}
+
+	link := m.KeyCreationURL(XMLKeyCharacter)
+	if !strings.HasSuffix(link, "?accessMask=6291458") {
+		t.Errorf("Wrong key creation link %s", link)
+	}
+
+	parsed, err := ParseCallList(strings.NewReader(callListFixture))
+	if err != nil || len(parsed.Calls) != len(list.Calls) {
+		t.Errorf("Error parsing call list %v", err)
+	}
+}
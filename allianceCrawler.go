@@ -0,0 +1,194 @@
+package eveapi
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// AllianceDirectoryEntry is an alliance and its member corporations.
+type AllianceDirectoryEntry struct {
+	ID                    int64
+	Name                  string
+	Ticker                string
+	ExecutorCorporationID int64
+	StartDate             time.Time
+	Deleted               bool
+	Corporations          []AllianceDirectoryCorporation
+}
+
+// AllianceDirectoryCorporation is a member corporation of an alliance. Ticker and
+// MemberCount are only filled when corporations are resolved.
+type AllianceDirectoryCorporation struct {
+	ID          int64
+	Name        string
+	Ticker      string
+	MemberCount int64
+}
+
+// AllianceCrawlProgress reports how far a crawl has got.
+type AllianceCrawlProgress struct {
+	Pages         int
+	PageCount     int
+	Alliances     int
+	AllianceCount int
+	Corporations  int
+}
+
+// AllianceCrawler builds a directory of every alliance by paging the alliances
+// collection into a pool of workers fetching each alliance.
+type AllianceCrawler struct {
+	client *EVEAPIClient
+
+	// Workers is the number of alliances fetched at once.
+	Workers int
+	// ResolveCorporations fetches the corporation sheet of every member corporation.
+	ResolveCorporations bool
+	// Progress is called as pages and alliances complete, if set.
+	Progress func(AllianceCrawlProgress)
+}
+
+func NewAllianceCrawler(c *EVEAPIClient) *AllianceCrawler {
+	return &AllianceCrawler{client: c, Workers: 10}
+}
+
+type allianceCrawlResult struct {
+	entry *AllianceDirectoryEntry
+	page  *AlliancesCollectionV2
+	err   error
+}
+
+// Crawl fetches every alliance and returns the directory ordered by alliance ID.
+// The crawl stops at the first error.
+func (a *AllianceCrawler) Crawl() ([]AllianceDirectoryEntry, error) {
+	workers := a.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	ids := make(chan int64)
+	results := make(chan allianceCrawlResult)
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	// Page through the collection feeding the workers. Pages can shift while they
+	// are walked, so alliances already fed are skipped.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ids)
+		seen := make(map[int64]bool)
+		page, err := a.client.AlliancesV2(1)
+		for {
+			if err != nil {
+				results <- allianceCrawlResult{err: err}
+				return
+			}
+			if page == nil {
+				return
+			}
+			results <- allianceCrawlResult{page: page}
+			for _, item := range page.Items {
+				if seen[item.ID] {
+					continue
+				}
+				seen[item.ID] = true
+				select {
+				case ids <- item.ID:
+				case <-done:
+					return
+				}
+			}
+			page, err = page.NextPage()
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				entry, err := a.fetch(id)
+				results <- allianceCrawlResult{entry: entry, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		directory []AllianceDirectoryEntry
+		progress  AllianceCrawlProgress
+		firstErr  error
+	)
+	for r := range results {
+		if firstErr != nil {
+			continue
+		}
+		if r.err != nil {
+			firstErr = r.err
+			close(done)
+			continue
+		}
+		if r.page != nil {
+			progress.Pages++
+			progress.PageCount = r.page.PageCount
+			progress.AllianceCount = r.page.TotalCount
+		}
+		if r.entry != nil {
+			directory = append(directory, *r.entry)
+			progress.Alliances++
+			if a.ResolveCorporations {
+				progress.Corporations += len(r.entry.Corporations)
+			}
+		}
+		if a.Progress != nil {
+			a.Progress(progress)
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(directory, func(i, j int) bool { return directory[i].ID < directory[j].ID })
+	return directory, nil
+}
+
+// Fetch an alliance and optionally its corporations.
+func (a *AllianceCrawler) fetch(id int64) (*AllianceDirectoryEntry, error) {
+	alliance, err := a.client.AllianceByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &AllianceDirectoryEntry{
+		ID:                    alliance.ID,
+		Name:                  alliance.Name,
+		Ticker:                alliance.ShortName,
+		ExecutorCorporationID: alliance.ExecutorCorporation.ID,
+		StartDate:             alliance.StartDate.Time,
+		Deleted:               alliance.Deleted,
+	}
+
+	seen := make(map[int64]bool)
+	for _, c := range alliance.Corporations {
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		corp := AllianceDirectoryCorporation{ID: c.ID, Name: c.Name}
+		if a.ResolveCorporations {
+			sheet, err := a.client.CorporationPublicSheetXML(c.ID)
+			if err != nil {
+				return nil, err
+			}
+			corp.Ticker = sheet.Ticker
+			corp.MemberCount = sheet.MemberCount
+		}
+		entry.Corporations = append(entry.Corporations, corp)
+	}
+	return entry, nil
+}
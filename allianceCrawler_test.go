@@ -0,0 +1,70 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAllianceCrawler(t *testing.T) {
+	var srv *httptest.Server
+	mu := sync.Mutex{}
+	fetched := make(map[string]int)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/alliances/":
+			// Alliance 2 is listed again on the second page.
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `{"items": [{"id": 2}, {"id": 3}], "totalCount": 3, "pageCount": 2}`)
+				return
+			}
+			fmt.Fprintf(w, `{"items": [{"id": 1}, {"id": 2}], "totalCount": 3, "pageCount": 2,
+				"next": {"href": "%s/alliances/?page=2"}}`, srv.URL)
+		case "/corp/CorporationSheet.xml.aspx":
+			id := r.URL.Query().Get("corporationID")
+			fmt.Fprintf(w, `<eveapi version="2"><result><corporationID>%s</corporationID><ticker>T%s</ticker><memberCount>5</memberCount></result></eveapi>`, id, id)
+		default:
+			var id int64
+			fmt.Sscanf(r.URL.Path, "/alliances/%d/", &id)
+			// Corporation 100 is listed twice.
+			fmt.Fprintf(w, `{"id": %d, "name": "Alliance %d", "shortName": "A%d", "executorCorporation": {"id": 100},
+				"corporations": [{"id": 100, "name": "Corp"}, {"id": %d, "name": "Other"}, {"id": 100, "name": "Corp"}]}`, id, id, id, id*1000)
+		}
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/", XML: srv.URL + "/"}, userAgent: USER_AGENT}
+
+	crawler := NewAllianceCrawler(c)
+	crawler.Workers = 3
+	crawler.ResolveCorporations = true
+	var last AllianceCrawlProgress
+	crawler.Progress = func(p AllianceCrawlProgress) { last = p }
+
+	directory, err := crawler.Crawl()
+	if err != nil {
+		t.Fatalf("Error crawling %v", err)
+	}
+	if len(directory) != 3 || directory[0].ID != 1 || directory[1].ID != 2 || directory[2].ID != 3 {
+		t.Fatalf("Wrong directory %+v", directory)
+	}
+	for _, id := range []int64{1, 2, 3} {
+		if n := fetched[fmt.Sprintf("/alliances/%d/", id)]; n != 1 {
+			t.Errorf("Alliance %d fetched %d times", id, n)
+		}
+	}
+	e := directory[1]
+	if e.Ticker != "A2" || e.ExecutorCorporationID != 100 || len(e.Corporations) != 2 {
+		t.Errorf("Wrong entry %+v", e)
+	}
+	if corp := e.Corporations[1]; corp.ID != 2000 || corp.Ticker != "T2000" || corp.MemberCount != 5 {
+		t.Errorf("Corporation not resolved %+v", corp)
+	}
+	if last.Pages != 2 || last.Alliances != 3 || last.AllianceCount != 3 || last.Corporations != 6 {
+		t.Errorf("Wrong progress %+v", last)
+	}
+}
@@ -0,0 +1,424 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AllianceSnapshot is the membership of an alliance at a point in time.
+type AllianceSnapshot struct {
+	AllianceID            int64
+	Name                  string
+	Ticker                string
+	ExecutorCorporationID int64
+	CorporationIDs        []int64
+	Deleted               bool
+	Time                  time.Time
+}
+
+// NewAllianceSnapshot takes a snapshot of an alliance.
+func NewAllianceSnapshot(a *AllianceV1, t time.Time) *AllianceSnapshot {
+	s := &AllianceSnapshot{
+		AllianceID:            a.ID,
+		Name:                  a.Name,
+		Ticker:                a.ShortName,
+		ExecutorCorporationID: a.ExecutorCorporation.ID,
+		Deleted:               a.Deleted,
+		Time:                  t,
+	}
+	for _, c := range a.Corporations {
+		s.CorporationIDs = append(s.CorporationIDs, c.ID)
+	}
+	sort.Slice(s.CorporationIDs, func(i, j int) bool { return s.CorporationIDs[i] < s.CorporationIDs[j] })
+	return s
+}
+
+// AllianceChangeType is the kind of change between alliance snapshots.
+type AllianceChangeType int
+
+const (
+	CorporationJoined AllianceChangeType = iota
+	CorporationLeft
+	ExecutorChanged
+	AllianceDeleted
+)
+
+func (t AllianceChangeType) String() string {
+	switch t {
+	case CorporationJoined:
+		return "joined"
+	case CorporationLeft:
+		return "left"
+	case ExecutorChanged:
+		return "executor changed"
+	case AllianceDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// AllianceChange is a change seen between two snapshots of an alliance.
+type AllianceChange struct {
+	Type       AllianceChangeType
+	AllianceID int64
+	// CorporationID is the corporation that joined or left, or the new executor.
+	CorporationID int64
+	// PreviousExecutorID is the old executor for ExecutorChanged.
+	PreviousExecutorID int64
+	Time               time.Time
+}
+
+// DiffAllianceSnapshots returns the changes from previous to current, timestamped
+// with the current snapshot. A nil previous snapshot has no changes.
+func DiffAllianceSnapshots(previous, current *AllianceSnapshot) []AllianceChange {
+	if previous == nil {
+		return nil
+	}
+
+	var changes []AllianceChange
+	change := func(t AllianceChangeType, corporationID int64) {
+		changes = append(changes, AllianceChange{
+			Type:          t,
+			AllianceID:    current.AllianceID,
+			CorporationID: corporationID,
+			Time:          current.Time,
+		})
+	}
+
+	before := make(map[int64]bool)
+	for _, id := range previous.CorporationIDs {
+		before[id] = true
+	}
+	after := make(map[int64]bool)
+	for _, id := range current.CorporationIDs {
+		after[id] = true
+		if !before[id] {
+			change(CorporationJoined, id)
+		}
+	}
+	for _, id := range previous.CorporationIDs {
+		if !after[id] {
+			change(CorporationLeft, id)
+		}
+	}
+
+	if previous.ExecutorCorporationID != current.ExecutorCorporationID {
+		change(ExecutorChanged, current.ExecutorCorporationID)
+		changes[len(changes)-1].PreviousExecutorID = previous.ExecutorCorporationID
+	}
+	if current.Deleted && !previous.Deleted {
+		change(AllianceDeleted, 0)
+	}
+
+	return changes
+}
+
+// AllianceHistoryStore stores alliance snapshots and the changes between them.
+type AllianceHistoryStore interface {
+	// LastAllianceSnapshot returns the latest snapshot of an alliance, or nil if there is none.
+	LastAllianceSnapshot(allianceID int64) (*AllianceSnapshot, error)
+	PutAllianceSnapshot(snapshot *AllianceSnapshot) error
+	AddAllianceChanges(changes []AllianceChange) error
+	// AllianceIDs returns the IDs of every alliance with a snapshot.
+	AllianceIDs() ([]int64, error)
+	// AllianceHistory returns the changes of an alliance, oldest first.
+	AllianceHistory(allianceID int64) ([]AllianceChange, error)
+	// CorporationHistory returns the changes involving a corporation, oldest first.
+	CorporationHistory(corporationID int64) ([]AllianceChange, error)
+}
+
+// MemoryAllianceHistoryStore is an AllianceHistoryStore that does not persist.
+type MemoryAllianceHistoryStore struct {
+	mu        sync.Mutex
+	snapshots map[int64]*AllianceSnapshot
+	changes   []AllianceChange
+}
+
+func NewMemoryAllianceHistoryStore() *MemoryAllianceHistoryStore {
+	return &MemoryAllianceHistoryStore{snapshots: make(map[int64]*AllianceSnapshot)}
+}
+
+func (s *MemoryAllianceHistoryStore) LastAllianceSnapshot(allianceID int64) (*AllianceSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshots[allianceID], nil
+}
+
+func (s *MemoryAllianceHistoryStore) PutAllianceSnapshot(snapshot *AllianceSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[snapshot.AllianceID] = snapshot
+	return nil
+}
+
+func (s *MemoryAllianceHistoryStore) AddAllianceChanges(changes []AllianceChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, changes...)
+	return nil
+}
+
+func (s *MemoryAllianceHistoryStore) AllianceIDs() ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]int64, 0, len(s.snapshots))
+	for id := range s.snapshots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// Changes returns every change recorded, oldest first.
+func (s *MemoryAllianceHistoryStore) Changes() []AllianceChange {
+	return s.history(func(c *AllianceChange) bool { return true })
+}
+
+func (s *MemoryAllianceHistoryStore) AllianceHistory(allianceID int64) ([]AllianceChange, error) {
+	return s.history(func(c *AllianceChange) bool {
+		return c.AllianceID == allianceID
+	}), nil
+}
+
+func (s *MemoryAllianceHistoryStore) CorporationHistory(corporationID int64) ([]AllianceChange, error) {
+	return s.history(func(c *AllianceChange) bool {
+		return c.CorporationID == corporationID || c.PreviousExecutorID == corporationID
+	}), nil
+}
+
+func (s *MemoryAllianceHistoryStore) history(match func(c *AllianceChange) bool) []AllianceChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var h []AllianceChange
+	for i := range s.changes {
+		if match(&s.changes[i]) {
+			h = append(h, s.changes[i])
+		}
+	}
+	return h
+}
+
+// FileAllianceHistoryStore is an AllianceHistoryStore kept in a file. Snapshots
+// and changes are appended to the file as they are saved and the file is compacted
+// when opened.
+type FileAllianceHistoryStore struct {
+	*MemoryAllianceHistoryStore
+	log *jsonLog
+}
+
+// A record of the file store, holding either a snapshot or changes.
+type allianceHistoryRecord struct {
+	Snapshot *AllianceSnapshot `json:",omitempty"`
+	Changes  []AllianceChange  `json:",omitempty"`
+}
+
+// NewFileAllianceHistoryStore opens a file store, loading the file if it exists.
+func NewFileAllianceHistoryStore(path string) (*FileAllianceHistoryStore, error) {
+	s := &FileAllianceHistoryStore{MemoryAllianceHistoryStore: NewMemoryAllianceHistoryStore()}
+
+	replay := func(dec *json.Decoder) error {
+		r := allianceHistoryRecord{}
+		if err := dec.Decode(&r); err != nil {
+			return err
+		}
+		if r.Snapshot != nil {
+			s.snapshots[r.Snapshot.AllianceID] = r.Snapshot
+		}
+		s.changes = append(s.changes, r.Changes...)
+		return nil
+	}
+	compact := func(enc *json.Encoder) error {
+		ids, _ := s.AllianceIDs()
+		for _, id := range ids {
+			if err := enc.Encode(allianceHistoryRecord{Snapshot: s.snapshots[id]}); err != nil {
+				return err
+			}
+		}
+		if len(s.changes) == 0 {
+			return nil
+		}
+		return enc.Encode(allianceHistoryRecord{Changes: s.changes})
+	}
+
+	log, err := openJSONLog(path, replay, compact)
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+	return s, nil
+}
+
+func (s *FileAllianceHistoryStore) PutAllianceSnapshot(snapshot *AllianceSnapshot) error {
+	s.MemoryAllianceHistoryStore.PutAllianceSnapshot(snapshot)
+	return s.log.append(allianceHistoryRecord{Snapshot: snapshot})
+}
+
+func (s *FileAllianceHistoryStore) AddAllianceChanges(changes []AllianceChange) error {
+	s.MemoryAllianceHistoryStore.AddAllianceChanges(changes)
+	return s.log.append(allianceHistoryRecord{Changes: changes})
+}
+
+// Close closes the file.
+func (s *FileAllianceHistoryStore) Close() error {
+	return s.log.close()
+}
+
+// AllianceTracker snapshots alliances on their cache schedules and records membership
+// changes in a history store. Alliances that drop out of the alliances collection
+// are recorded as deleted, with all of their corporations leaving.
+type AllianceTracker struct {
+	client   *EVEAPIClient
+	store    AllianceHistoryStore
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	next map[int64]time.Time
+
+	// Interval is the minimum time between polls.
+	Interval time.Duration
+	// OnError is called when a poll fails, if set.
+	OnError func(err error)
+}
+
+func NewAllianceTracker(c *EVEAPIClient, store AllianceHistoryStore) *AllianceTracker {
+	return &AllianceTracker{
+		client:   c,
+		store:    store,
+		next:     make(map[int64]time.Time),
+		stop:     make(chan struct{}),
+		Interval: time.Hour,
+	}
+}
+
+// Start polls in the background until Stop is called.
+func (t *AllianceTracker) Start() {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for {
+			next, err := t.Poll()
+			if err != nil && t.OnError != nil {
+				t.OnError(err)
+			}
+			select {
+			case <-t.stop:
+				return
+			case <-time.After(next.Sub(time.Now())):
+			}
+		}
+	}()
+}
+
+// Stop stops polling. Further calls do nothing.
+func (t *AllianceTracker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+		t.wg.Wait()
+	})
+}
+
+// Poll walks the alliances collection once, snapshotting alliances whose cache has
+// expired, and returns the time of the next poll. Once the whole collection has
+// been walked, alliances in the store that were not listed are closed.
+func (t *AllianceTracker) Poll() (time.Time, error) {
+	now := time.Now()
+	next := now.Add(t.Interval)
+	seen := make(map[int64]bool)
+
+	page, err := t.client.AlliancesV2(1)
+	if err == nil && page != nil && page.CacheUntil.After(next) {
+		next = page.CacheUntil
+	}
+
+	for {
+		if err != nil {
+			return next, err
+		}
+		if page == nil {
+			break
+		}
+
+		for _, item := range page.Items {
+			select {
+			case <-t.stop:
+				return next, nil
+			default:
+			}
+			seen[item.ID] = true
+
+			t.mu.Lock()
+			due := !now.Before(t.next[item.ID])
+			t.mu.Unlock()
+			if !due {
+				continue
+			}
+			if err := t.Snapshot(item.ID); err != nil {
+				return next, err
+			}
+		}
+		page, err = page.NextPage()
+	}
+
+	return next, t.closeMissing(seen)
+}
+
+// Snapshot fetches an alliance now and records any changes since its last snapshot.
+func (t *AllianceTracker) Snapshot(allianceID int64) error {
+	a, err := t.client.AllianceByID(allianceID)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.next[allianceID] = a.CacheUntil
+	t.mu.Unlock()
+
+	return t.record(NewAllianceSnapshot(a, time.Now().UTC()))
+}
+
+// Record a snapshot and the changes since the last one.
+func (t *AllianceTracker) record(current *AllianceSnapshot) error {
+	previous, err := t.store.LastAllianceSnapshot(current.AllianceID)
+	if err != nil {
+		return err
+	}
+
+	if changes := DiffAllianceSnapshots(previous, current); len(changes) > 0 {
+		if err := t.store.AddAllianceChanges(changes); err != nil {
+			return err
+		}
+	}
+	return t.store.PutAllianceSnapshot(current)
+}
+
+// Close the alliances in the store that are no longer listed.
+func (t *AllianceTracker) closeMissing(seen map[int64]bool) error {
+	ids, err := t.store.AllianceIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		previous, err := t.store.LastAllianceSnapshot(id)
+		if err != nil {
+			return err
+		}
+		if previous == nil || previous.Deleted {
+			continue
+		}
+
+		closed := *previous
+		closed.CorporationIDs = nil
+		closed.Deleted = true
+		closed.Time = time.Now().UTC()
+		if err := t.record(&closed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package eveapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAllianceSnapshotDiff(t *testing.T) {
+	before := &AllianceSnapshot{
+		AllianceID:            99000001,
+		ExecutorCorporationID: 1,
+		CorporationIDs:        []int64{1, 2, 3},
+	}
+	after := &AllianceSnapshot{
+		AllianceID:            99000001,
+		ExecutorCorporationID: 2,
+		CorporationIDs:        []int64{2, 3, 4},
+		Time:                  time.Now(),
+	}
+
+	if changes := DiffAllianceSnapshots(nil, after); len(changes) != 0 {
+		t.Errorf("First snapshot should have no changes %v", changes)
+	}
+
+	changes := DiffAllianceSnapshots(before, after)
+	expected := []AllianceChange{
+		{Type: CorporationJoined, CorporationID: 4},
+		{Type: CorporationLeft, CorporationID: 1},
+		{Type: ExecutorChanged, CorporationID: 2, PreviousExecutorID: 1},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %v", len(expected), changes)
+	}
+	for i, e := range expected {
+		c := changes[i]
+		if c.Type != e.Type || c.CorporationID != e.CorporationID || c.PreviousExecutorID != e.PreviousExecutorID {
+			t.Errorf("Change %d is %v, expected %v", i, c, e)
+		}
+	}
+}
+
+func TestFileAllianceHistoryStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eveapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "alliances.json")
+
+	s, err := NewFileAllianceHistoryStore(path)
+	if err != nil {
+		t.Fatalf("Error opening store %v", err)
+	}
+	s.PutAllianceSnapshot(&AllianceSnapshot{AllianceID: 99000001, CorporationIDs: []int64{1, 2}})
+	s.AddAllianceChanges([]AllianceChange{{Type: CorporationLeft, AllianceID: 99000001, CorporationID: 5}})
+	s.PutAllianceSnapshot(&AllianceSnapshot{AllianceID: 99000001, CorporationIDs: []int64{1}})
+	s.AddAllianceChanges([]AllianceChange{{Type: CorporationLeft, AllianceID: 99000001, CorporationID: 2}})
+	s.Close()
+
+	// Reopen twice to read both the appended and the compacted file.
+	for i := 0; i < 2; i++ {
+		s, err = NewFileAllianceHistoryStore(path)
+		if err != nil {
+			t.Fatalf("Error reopening store %v", err)
+		}
+		if snap, _ := s.LastAllianceSnapshot(99000001); snap == nil || len(snap.CorporationIDs) != 1 {
+			t.Errorf("Latest snapshot not persisted")
+		}
+		if ids, _ := s.AllianceIDs(); len(ids) != 1 || ids[0] != 99000001 {
+			t.Errorf("Wrong alliance IDs %v", ids)
+		}
+		if h, _ := s.CorporationHistory(5); len(h) != 1 {
+			t.Errorf("Changes not persisted")
+		}
+		if changes := s.Changes(); len(changes) != 2 || changes[1].CorporationID != 2 {
+			t.Errorf("Wrong changes %v", changes)
+		}
+		s.Close()
+	}
+}
+
+// Serve alliances from the map, which the test may change between polls.
+func testAllianceServer(t *testing.T, mu *sync.Mutex, alliances map[int64][]int64) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/alliances/" {
+			items := ""
+			for id := range alliances {
+				if items != "" {
+					items += ","
+				}
+				items += fmt.Sprintf(`{"id": %d, "href": "%s/alliances/%d/"}`, id, srv.URL, id)
+			}
+			fmt.Fprintf(w, `{"items": [%s]}`, items)
+			return
+		}
+		var id int64
+		fmt.Sscanf(r.URL.Path, "/alliances/%d/", &id)
+		corps := ""
+		for i, c := range alliances[id] {
+			if i > 0 {
+				corps += ","
+			}
+			corps += fmt.Sprintf(`{"id": %d}`, c)
+		}
+		fmt.Fprintf(w, `{"id": %d, "executorCorporation": {"id": %d}, "corporations": [%s]}`, id, alliances[id][0], corps)
+	}))
+	return srv
+}
+
+func TestAllianceTrackerClosesMissing(t *testing.T) {
+	mu := sync.Mutex{}
+	alliances := map[int64][]int64{1: {10, 11}, 2: {20}}
+	srv := testAllianceServer(t, &mu, alliances)
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	store := NewMemoryAllianceHistoryStore()
+	tracker := NewAllianceTracker(c, store)
+
+	if _, err := tracker.Poll(); err != nil {
+		t.Fatalf("Error polling %v", err)
+	}
+	mu.Lock()
+	delete(alliances, 2)
+	mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if _, err := tracker.Poll(); err != nil {
+			t.Fatalf("Error polling %v", err)
+		}
+	}
+
+	// The disbanded alliance is closed once, with its corporation leaving.
+	h, _ := store.AllianceHistory(2)
+	if len(h) != 2 || h[0].Type != CorporationLeft || h[0].CorporationID != 20 || h[1].Type != AllianceDeleted {
+		t.Errorf("Wrong history of the disbanded alliance %v", h)
+	}
+	if snap, _ := store.LastAllianceSnapshot(2); snap == nil || !snap.Deleted || len(snap.CorporationIDs) != 0 {
+		t.Errorf("Disbanded alliance not closed %+v", snap)
+	}
+	if h, _ := store.AllianceHistory(1); len(h) != 0 {
+		t.Errorf("Listed alliance has changes %v", h)
+	}
+}
+
+func TestAllianceTrackerSnapshotWhileRunning(t *testing.T) {
+	mu := sync.Mutex{}
+	srv := testAllianceServer(t, &mu, map[int64][]int64{1: {10}, 2: {20}})
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	tracker := NewAllianceTracker(c, NewMemoryAllianceHistoryStore())
+	tracker.Interval = time.Millisecond
+
+	tracker.Start()
+	for i := 0; i < 20; i++ {
+		if err := tracker.Snapshot(int64(i%2 + 1)); err != nil {
+			t.Errorf("Error taking snapshot %v", err)
+		}
+	}
+	tracker.Stop()
+}
+
+func TestAllianceTrackerStopTwice(t *testing.T) {
+	mu := sync.Mutex{}
+	srv := testAllianceServer(t, &mu, map[int64][]int64{1: {10}})
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	tracker := NewAllianceTracker(c, NewMemoryAllianceHistoryStore())
+	tracker.Start()
+	tracker.Stop()
+	tracker.Stop()
+}
@@ -1,6 +1,11 @@
 package eveapi
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
 
 // CCP basic XML Frame
 type xmlAPIFrame struct {
@@ -15,6 +20,28 @@ type XMLAPIKey struct {
 	KeyID int64
 }
 
+// xmlKeyInfoCache keeps the info of the keys a client has used, so keys stay
+// plain values that can be copied.
+type xmlKeyInfoCache struct {
+	mu    sync.Mutex
+	infos map[XMLAPIKey]*APIKeyInfoXML
+}
+
+func (c *xmlKeyInfoCache) get(key XMLAPIKey) *APIKeyInfoXML {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.infos[key]
+}
+
+func (c *xmlKeyInfoCache) put(key XMLAPIKey, info *APIKeyInfoXML) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.infos == nil {
+		c.infos = make(map[XMLAPIKey]*APIKeyInfoXML)
+	}
+	c.infos[key] = info
+}
+
 // IsValidVCode validates a vCode for the XML API meets basic requirements
 func (c *XMLAPIKey) IsValidVCode() bool {
 	if m, _ := regexp.MatchString("^[a-zA-Z0-9]+$", c.VCode); !m {
@@ -27,3 +54,66 @@ func (c *XMLAPIKey) IsValidVCode() bool {
 
 	return true
 }
+
+// XMLKeyType is the type of an XML API key.
+type XMLKeyType string
+
+const (
+	XMLKeyAccount     XMLKeyType = "Account"
+	XMLKeyCharacter   XMLKeyType = "Character"
+	XMLKeyCorporation XMLKeyType = "Corporation"
+)
+
+// APIKeyInfoXML returned data from XML API
+type APIKeyInfoXML struct {
+	xmlAPIFrame
+	Key struct {
+		AccessMask int64      `xml:"accessMask,attr"`
+		Type       XMLKeyType `xml:"type,attr"`
+		Expires    EVEXMLTime `xml:"expires,attr"`
+		Characters []struct {
+			CharacterID     int64  `xml:"characterID,attr"`
+			CharacterName   string `xml:"characterName,attr"`
+			CorporationID   int64  `xml:"corporationID,attr"`
+			CorporationName string `xml:"corporationName,attr"`
+			AllianceID      int64  `xml:"allianceID,attr"`
+			AllianceName    string `xml:"allianceName,attr"`
+			FactionID       int64  `xml:"factionID,attr"`
+			FactionName     string `xml:"factionName,attr"`
+		} `xml:"rowset>row"`
+	} `xml:"result>key"`
+}
+
+// HasCharacter reports whether the key covers characterID.
+func (i *APIKeyInfoXML) HasCharacter(characterID int64) bool {
+	for _, c := range i.Key.Characters {
+		if c.CharacterID == characterID {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyInfoXML queries the XML API for the type, access mask, expiry and
+// characters of a key. The client keeps the result to check calls made with the key.
+func (c *EVEAPIClient) APIKeyInfoXML(key *XMLAPIKey) (*APIKeyInfoXML, error) {
+	w := &APIKeyInfoXML{}
+
+	url := c.base.XML + fmt.Sprintf("account/APIKeyInfo.xml.aspx?keyID=%d&vCode=%s", key.KeyID, key.VCode)
+	_, err := c.doXML("GET", url, nil, w, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.keyInfo.put(*key, w)
+	return w, nil
+}
+
+// cachedInfo returns the key info, fetching it if it is missing or stale.
+func (k *XMLAPIKey) cachedInfo(c *EVEAPIClient) (*APIKeyInfoXML, error) {
+	info := c.keyInfo.get(*k)
+	if info != nil && (info.CachedUntil.IsZero() || time.Now().Before(info.CachedUntil.Time)) {
+		return info, nil
+	}
+	return c.APIKeyInfoXML(k)
+}
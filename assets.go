@@ -0,0 +1,272 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// AssetFlag is where an item sits inside its location or container.
+type AssetFlag int64
+
+// Inventory flags of assets, in addition to those used by fittings.
+const (
+	FlagHangar      AssetFlag = 4
+	FlagAssetSafety AssetFlag = 36
+	FlagShipHangar  AssetFlag = 90
+	FlagCorpSAG2    AssetFlag = 116
+	FlagCorpSAG3    AssetFlag = 117
+	FlagCorpSAG4    AssetFlag = 118
+	FlagCorpSAG5    AssetFlag = 119
+	FlagCorpSAG6    AssetFlag = 120
+	FlagCorpSAG7    AssetFlag = 121
+	FlagDeliveries  AssetFlag = 173
+)
+
+var assetFlagNames = map[AssetFlag]string{
+	0:               "None",
+	FlagHangar:      "Hangar",
+	FlagCargo:       "Cargo",
+	FlagAssetSafety: "AssetSafety",
+	62:              "CorpDeliveries",
+	FlagDroneBay:    "DroneBay",
+	88:              "Booster",
+	89:              "Implant",
+	FlagShipHangar:  "ShipHangar",
+	FlagCorpSAG2:    "CorpSAG2",
+	FlagCorpSAG3:    "CorpSAG3",
+	FlagCorpSAG4:    "CorpSAG4",
+	FlagCorpSAG5:    "CorpSAG5",
+	FlagCorpSAG6:    "CorpSAG6",
+	FlagCorpSAG7:    "CorpSAG7",
+	133:             "SpecializedFuelBay",
+	134:             "SpecializedOreHold",
+	155:             "FleetHangar",
+	FlagFighterBay:  "FighterBay",
+	FlagDeliveries:  "Deliveries",
+}
+
+// Asset flag names of the fitting racks by their first slot.
+var assetRackNames = map[AssetFlag]string{
+	FlagLoSlot0:        "LoSlot",
+	FlagMedSlot0:       "MedSlot",
+	FlagHiSlot0:        "HiSlot",
+	FlagRigSlot0:       "RigSlot",
+	FlagSubSystemSlot0: "SubSystemSlot",
+}
+
+func (f AssetFlag) String() string {
+	if r := rackForFlag(f); r != nil {
+		return fmt.Sprintf("%s%d", assetRackNames[r.flag], int64(f-r.flag))
+	}
+	if name, ok := assetFlagNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Flag(%d)", int64(f))
+}
+
+// Fitted reports whether the flag is a module, rig or subsystem slot.
+func (f AssetFlag) Fitted() bool {
+	return rackForFlag(f) != nil
+}
+
+// AssetXML is an item of an asset list and the items inside it.
+type AssetXML struct {
+	ItemID int64 `xml:"itemID,attr"`
+	// LocationID is only set on top level items.
+	LocationID int64     `xml:"locationID,attr"`
+	TypeID     int64     `xml:"typeID,attr"`
+	Quantity   int64     `xml:"quantity,attr"`
+	Flag       AssetFlag `xml:"flag,attr"`
+	Singleton  bool      `xml:"singleton,attr"`
+	// RawQuantity is -1 for assembled items and -2 for blueprint copies.
+	RawQuantity int64      `xml:"rawQuantity,attr"`
+	Contents    []AssetXML `xml:"rowset>row"`
+}
+
+// Packaged reports whether the item is stacked rather than assembled.
+func (a *AssetXML) Packaged() bool {
+	return !a.Singleton
+}
+
+// BlueprintCopy reports whether the item is a blueprint copy.
+func (a *AssetXML) BlueprintCopy() bool {
+	return a.RawQuantity == -2
+}
+
+// AssetListXML returned data from XML API
+type AssetListXML struct {
+	xmlAPIFrame
+	Assets []AssetXML `xml:"result>rowset>row"`
+}
+
+// CharacterAssetListXML queries the XML API for a character's assets as a tree.
+func (c *EVEAPIClient) CharacterAssetListXML(auth XMLAuth, characterID int64) (*AssetListXML, error) {
+	w := &AssetListXML{}
+
+	params := url.Values{"characterID": {fmt.Sprintf("%d", characterID)}}
+	if err := c.doAuthedXML(auth, xmlCharAssetList, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CorporationAssetListXML queries the XML API for the assets of the character's
+// corporation as a tree.
+func (c *EVEAPIClient) CorporationAssetListXML(auth XMLAuth, characterID int64) (*AssetListXML, error) {
+	w := &AssetListXML{}
+
+	params := url.Values{"characterID": {fmt.Sprintf("%d", characterID)}}
+	if err := c.doAuthedXML(auth, xmlCorpAssetList, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// AssetLocationKind is the kind of place a top level item is in.
+type AssetLocationKind int
+
+const (
+	LocationUnknown AssetLocationKind = iota
+	LocationStation
+	LocationSolarSystem
+	LocationStructure
+	// LocationOffice is a corporation office in a station.
+	LocationOffice
+)
+
+func (k AssetLocationKind) String() string {
+	switch k {
+	case LocationStation:
+		return "station"
+	case LocationSolarSystem:
+		return "solar system"
+	case LocationStructure:
+		return "structure"
+	case LocationOffice:
+		return "office"
+	}
+	return "unknown"
+}
+
+// AssetLocation is a resolved asset locationID. ID is the station, solar system or
+// structure, and the station for offices.
+type AssetLocation struct {
+	Kind AssetLocationKind
+	ID   int64
+}
+
+// ResolveAssetLocation works out what a locationID refers to from its range.
+// Office folders are offset from the ID of their station, by one more for NPC
+// stations than for outposts.
+func ResolveAssetLocation(locationID int64) AssetLocation {
+	switch {
+	case locationID >= 66000000 && locationID < 66014934:
+		return AssetLocation{LocationOffice, locationID - 6000001}
+	case locationID >= 66014934 && locationID < 68000000:
+		return AssetLocation{LocationOffice, locationID - 6000000}
+	case locationID >= 60000000 && locationID < 64000000:
+		return AssetLocation{LocationStation, locationID}
+	case locationID >= 30000000 && locationID < 33000000:
+		return AssetLocation{LocationSolarSystem, locationID}
+	case locationID >= 1000000000000:
+		return AssetLocation{LocationStructure, locationID}
+	}
+	return AssetLocation{LocationUnknown, locationID}
+}
+
+// FlatAsset is an item of an asset tree with its place in the tree.
+type FlatAsset struct {
+	ItemID      int64
+	TypeID      int64
+	Quantity    int64
+	Flag        AssetFlag
+	Singleton   bool
+	RawQuantity int64
+	// LocationID is that of the top level item the asset is in.
+	LocationID int64
+	Location   AssetLocation
+	// ParentID is the item containing the asset, or zero at the top level.
+	ParentID int64
+	Depth    int
+}
+
+// Flatten returns every item of the tree, each parent before its contents.
+func (l *AssetListXML) Flatten() []FlatAsset {
+	var flat []FlatAsset
+	var walk func(assets []AssetXML, locationID int64, parentID int64, depth int)
+	walk = func(assets []AssetXML, locationID int64, parentID int64, depth int) {
+		for i := range assets {
+			a := &assets[i]
+			if parentID == 0 {
+				locationID = a.LocationID
+			}
+			flat = append(flat, FlatAsset{
+				ItemID:      a.ItemID,
+				TypeID:      a.TypeID,
+				Quantity:    a.Quantity,
+				Flag:        a.Flag,
+				Singleton:   a.Singleton,
+				RawQuantity: a.RawQuantity,
+				LocationID:  locationID,
+				Location:    ResolveAssetLocation(locationID),
+				ParentID:    parentID,
+				Depth:       depth,
+			})
+			walk(a.Contents, locationID, a.ItemID, depth+1)
+		}
+	}
+	walk(l.Assets, 0, 0, 0)
+	return flat
+}
+
+// Totals returns the quantity of each typeID at each location, counting items
+// inside containers and ships.
+func (l *AssetListXML) Totals() map[AssetLocation]map[int64]int64 {
+	totals := make(map[AssetLocation]map[int64]int64)
+	for _, a := range l.Flatten() {
+		types, ok := totals[a.Location]
+		if !ok {
+			types = make(map[int64]int64)
+			totals[a.Location] = types
+		}
+		types[a.TypeID] += a.Quantity
+	}
+	return totals
+}
+
+// Find returns the items matching fn, each parent before its contents.
+func (l *AssetListXML) Find(fn func(a *FlatAsset) bool) []FlatAsset {
+	var found []FlatAsset
+	for _, a := range l.Flatten() {
+		if fn(&a) {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// FindTypes returns the items of any of the typeIDs.
+func (l *AssetListXML) FindTypes(typeIDs ...int64) []FlatAsset {
+	want := make(map[int64]bool)
+	for _, id := range typeIDs {
+		want[id] = true
+	}
+	return l.Find(func(a *FlatAsset) bool { return want[a.TypeID] })
+}
+
+// Item returns the item with itemID and its contents, or nil if it is not in the tree.
+func (l *AssetListXML) Item(itemID int64) *AssetXML {
+	var find func(assets []AssetXML) *AssetXML
+	find = func(assets []AssetXML) *AssetXML {
+		for i := range assets {
+			if assets[i].ItemID == itemID {
+				return &assets[i]
+			}
+			if a := find(assets[i].Contents); a != nil {
+				return a
+			}
+		}
+		return nil
+	}
+	return find(l.Assets)
+}
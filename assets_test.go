@@ -0,0 +1,91 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestAssetList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/corp/AssetList.xml.aspx" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("accessType") != "corporation" {
+			t.Errorf("Corporation assets requested without accessType")
+		}
+		fmt.Fprint(w, `<eveapi version="2"><result><rowset name="assets" key="itemID" columns="itemID,locationID,typeID,quantity,flag,singleton">
+			<row itemID="1" locationID="66014934" typeID="27" quantity="1" flag="4" singleton="1" rawQuantity="-1">
+				<rowset name="contents" key="itemID" columns="itemID,typeID,quantity,flag,singleton">
+					<row itemID="2" typeID="34" quantity="100" flag="116" singleton="0" />
+					<row itemID="3" typeID="17476" quantity="1" flag="116" singleton="1" rawQuantity="-1">
+						<rowset name="contents" key="itemID" columns="itemID,typeID,quantity,flag,singleton">
+							<row itemID="4" typeID="34" quantity="50" flag="5" singleton="0" />
+							<row itemID="5" typeID="2046" quantity="1" flag="27" singleton="1" rawQuantity="-1" />
+						</rowset>
+					</row>
+				</rowset>
+			</row>
+			<row itemID="6" locationID="30000142" typeID="34" quantity="7" flag="0" singleton="0" />
+			<row itemID="7" locationID="1021975535893" typeID="995" quantity="1" flag="0" singleton="0" rawQuantity="-2" />
+		</rowset></result></eveapi>`)
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{XML: srv.URL + "/"}, userAgent: USER_AGENT}
+	auth := NewXMLTokenAuth(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+
+	l, err := c.CorporationAssetListXML(auth, 1)
+	if err != nil {
+		t.Fatalf("Error getting assets %v", err)
+	}
+	if len(l.Assets) != 3 || len(l.Assets[0].Contents) != 2 || len(l.Assets[0].Contents[1].Contents) != 2 {
+		t.Fatalf("Wrong asset tree %+v", l.Assets)
+	}
+	if l.Assets[0].Packaged() || !l.Assets[0].Contents[0].Packaged() || !l.Assets[2].BlueprintCopy() {
+		t.Errorf("Wrong packaged state")
+	}
+
+	flat := l.Flatten()
+	if len(flat) != 7 {
+		t.Fatalf("Expected 7 flat assets, got %d", len(flat))
+	}
+	office := AssetLocation{LocationOffice, 60014934}
+	if f := flat[3]; f.ItemID != 4 || f.ParentID != 3 || f.Depth != 2 || f.LocationID != 66014934 || f.Location != office {
+		t.Errorf("Wrong nested asset %+v", f)
+	}
+	if flat[4].Flag.String() != "HiSlot0" || !flat[4].Flag.Fitted() || flat[1].Flag != FlagCorpSAG2 || FlagCorpSAG2.String() != "CorpSAG2" {
+		t.Errorf("Wrong flag names %s %s", flat[4].Flag, flat[1].Flag)
+	}
+
+	totals := l.Totals()
+	if totals[office][34] != 150 || totals[AssetLocation{LocationSolarSystem, 30000142}][34] != 7 {
+		t.Errorf("Wrong totals %v", totals)
+	}
+	if loc := ResolveAssetLocation(1021975535893); loc.Kind != LocationStructure {
+		t.Errorf("Wrong structure location %v", loc)
+	}
+	// NPC station offices are one further from their station than outpost offices.
+	for locationID, stationID := range map[int64]int64{
+		66000000: 59999999,
+		66014933: 60014932,
+		66014934: 60014934,
+		67000000: 61000000,
+	} {
+		if loc := ResolveAssetLocation(locationID); loc != (AssetLocation{LocationOffice, stationID}) {
+			t.Errorf("Office %d resolved to %v, expected station %d", locationID, loc, stationID)
+		}
+	}
+
+	if found := l.FindTypes(34); len(found) != 3 {
+		t.Errorf("Expected 3 stacks of type 34, got %d", len(found))
+	}
+	if item := l.Item(3); item == nil || len(item.Contents) != 2 {
+		t.Errorf("Wrong item %+v", item)
+	}
+	if l.Item(99) != nil {
+		t.Errorf("Found a missing item")
+	}
+}
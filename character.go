@@ -2,6 +2,7 @@ package eveapi
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 
 	"golang.org/x/oauth2"
@@ -51,42 +52,47 @@ func (c *EVEAPIClient) CharacterInfoXML(characterID int64) (*CharacterInfoXML, e
 
 type WalletJournalXML struct {
 	xmlAPIFrame
-	Entries []struct {
-		RefID         int64      `xml:"refID,attr"`
-		RefTypeID     int64      `xml:"refTypeID,attr"`
-		OwnerName1    string     `xml:"ownerName1,attr"`
-		OwnerID1      int64      `xml:"ownerID1,attr"`
-		OwnerName2    string     `xml:"ownerName2,attr"`
-		OwnerID2      int64      `xml:"ownerID2,attr"`
-		ArgName1      string     `xml:"argName1,attr"`
-		ArgID1        int64      `xml:"argID1,attr"`
-		Amount        float64    `xml:"amount,attr"`
-		Balance       float64    `xml:"balance,attr"`
-		Reason        string     `xml:"reason,attr"`
-		TaxReceiverID int64      `xml:"taxReceiverID,attr"`
-		TaxAmount     float64    `xml:"taxAmount,attr"`
-		Date          EVEXMLTime `xml:"date,attr"`
-	} `xml:"result>rowset>row"`
+	Entries []WalletJournalEntryXML `xml:"result>rowset>row"`
 }
 
-// GetCharacterInfo queries the XML API for a given characterID.
+// WalletJournalEntryXML is a row of a wallet journal.
+type WalletJournalEntryXML struct {
+	RefID         int64      `xml:"refID,attr"`
+	RefTypeID     int64      `xml:"refTypeID,attr"`
+	OwnerName1    string     `xml:"ownerName1,attr"`
+	OwnerID1      int64      `xml:"ownerID1,attr"`
+	OwnerName2    string     `xml:"ownerName2,attr"`
+	OwnerID2      int64      `xml:"ownerID2,attr"`
+	ArgName1      string     `xml:"argName1,attr"`
+	ArgID1        int64      `xml:"argID1,attr"`
+	Amount        float64    `xml:"amount,attr"`
+	Balance       float64    `xml:"balance,attr"`
+	Reason        string     `xml:"reason,attr"`
+	TaxReceiverID int64      `xml:"taxReceiverID,attr"`
+	TaxAmount     float64    `xml:"taxAmount,attr"`
+	Date          EVEXMLTime `xml:"date,attr"`
+}
+
+// CharacterWalletJournalXML queries the XML API for a page of a character's wallet
+// journal with an SSO token, walking back from fromID when it is set.
 func (c *EVEAPIClient) CharacterWalletJournalXML(auth oauth2.TokenSource, characterID int64, fromID int64) (*WalletJournalXML, error) {
+	return c.CharacterWalletJournalAuthXML(NewXMLTokenAuth(auth), characterID, fromID)
+}
+
+// CharacterWalletJournalAuthXML is CharacterWalletJournalXML authenticated with an
+// *XMLAPIKey or any other XMLAuth.
+func (c *EVEAPIClient) CharacterWalletJournalAuthXML(auth XMLAuth, characterID int64, fromID int64) (*WalletJournalXML, error) {
 	w := &WalletJournalXML{}
 
-	from := ""
-	if fromID > 0 {
-		from = fmt.Sprintf("&fromID=%d", fromID)
+	params := url.Values{
+		"characterID": {fmt.Sprintf("%d", characterID)},
+		"rowCount":    {fmt.Sprintf("%d", walletRowCount)},
 	}
-
-	tok, err := auth.Token()
-	if err != nil {
-		return nil, err
+	if fromID > 0 {
+		params.Set("fromID", fmt.Sprintf("%d", fromID))
 	}
 
-	url := c.base.XML + fmt.Sprintf("char/WalletJournal.xml.aspx?characterID=%d&accessToken=%s&rowCount=2560%s", characterID, tok.AccessToken, from)
-
-	_, err = c.doXML("GET", url, nil, w, nil, nil)
-	if err != nil {
+	if err := c.doAuthedXML(auth, xmlCharWalletJournal, characterID, params, w); err != nil {
 		return nil, err
 	}
 	return w, nil
@@ -111,44 +117,49 @@ func (c *EVEAPIClient) RefTypesXML() (*RefTypeXML, error) {
 
 type WalletTransactionXML struct {
 	xmlAPIFrame
-	Entries []struct {
-		TransactionDateTime  EVEXMLTime `xml:"transactionDateTime,attr"`
-		TransactionID        int64      `xml:"transactionID,attr"`
-		Quantity             int64      `xml:"quantity,attr"`
-		TypeName             string     `xml:"typeName,attr"`
-		TypeID               int64      `xml:"typeID,attr"`
-		Price                float64    `xml:"price,attr"`
-		ClientID             int64      `xml:"clientID,attr"`
-		ClientTypeID         int64      `xml:"clientTypeID,attr"`
-		ClientName           string     `xml:"clientName,attr"`
-		CharacterID          int64      `xml:"characterID,attr"`
-		CharacterName        string     `xml:"characterName,attr"`
-		StationID            int64      `xml:"stationID,attr"`
-		StationName          string     `xml:"stationName,attr"`
-		TransactionType      string     `xml:"transactionType,attr"`
-		TransactionFor       string     `xml:"transactionFor,attr"`
-		JournalTransactionID int64      `xml:"journalTransactionID,attr"`
-	} `xml:"result>rowset>row"`
+	Entries []WalletTransactionEntryXML `xml:"result>rowset>row"`
 }
 
-// GetCharacterInfo queries the XML API for a given characterID.
+// WalletTransactionEntryXML is a row of wallet transactions.
+type WalletTransactionEntryXML struct {
+	TransactionDateTime  EVEXMLTime `xml:"transactionDateTime,attr"`
+	TransactionID        int64      `xml:"transactionID,attr"`
+	Quantity             int64      `xml:"quantity,attr"`
+	TypeName             string     `xml:"typeName,attr"`
+	TypeID               int64      `xml:"typeID,attr"`
+	Price                float64    `xml:"price,attr"`
+	ClientID             int64      `xml:"clientID,attr"`
+	ClientTypeID         int64      `xml:"clientTypeID,attr"`
+	ClientName           string     `xml:"clientName,attr"`
+	CharacterID          int64      `xml:"characterID,attr"`
+	CharacterName        string     `xml:"characterName,attr"`
+	StationID            int64      `xml:"stationID,attr"`
+	StationName          string     `xml:"stationName,attr"`
+	TransactionType      string     `xml:"transactionType,attr"`
+	TransactionFor       string     `xml:"transactionFor,attr"`
+	JournalTransactionID int64      `xml:"journalTransactionID,attr"`
+}
+
+// CharacterWalletTransactionXML queries the XML API for a page of a character's
+// wallet transactions with an SSO token, walking back from fromID when it is set.
 func (c *EVEAPIClient) CharacterWalletTransactionXML(auth oauth2.TokenSource, characterID int64, fromID int64) (*WalletTransactionXML, error) {
+	return c.CharacterWalletTransactionAuthXML(NewXMLTokenAuth(auth), characterID, fromID)
+}
+
+// CharacterWalletTransactionAuthXML is CharacterWalletTransactionXML authenticated
+// with an *XMLAPIKey or any other XMLAuth.
+func (c *EVEAPIClient) CharacterWalletTransactionAuthXML(auth XMLAuth, characterID int64, fromID int64) (*WalletTransactionXML, error) {
 	w := &WalletTransactionXML{}
 
-	from := ""
-	if fromID > 0 {
-		from = fmt.Sprintf("&fromID=%d", fromID)
+	params := url.Values{
+		"characterID": {fmt.Sprintf("%d", characterID)},
+		"rowCount":    {fmt.Sprintf("%d", walletRowCount)},
 	}
-
-	tok, err := auth.Token()
-	if err != nil {
-		return nil, err
+	if fromID > 0 {
+		params.Set("fromID", fmt.Sprintf("%d", fromID))
 	}
 
-	url := c.base.XML + fmt.Sprintf("char/WalletTransactions.xml.aspx?characterID=%d&accessToken=%s&rowCount=2560%s", characterID, tok.AccessToken, from)
-
-	_, err = c.doXML("GET", url, nil, w, nil, nil)
-	if err != nil {
+	if err := c.doAuthedXML(auth, xmlCharWalletTransactions, characterID, params, w); err != nil {
 		return nil, err
 	}
 	return w, nil
@@ -210,3 +221,11 @@ func ValidCharacterName(name string) bool {
 	}
 	return true
 }
+
+// Use the linked href if we have it, otherwise build it from the character ID.
+func (c *CharacterV4) href(link simpleHref, path string) string {
+	if link.Href != "" {
+		return link.Href
+	}
+	return c.base.CREST + fmt.Sprintf("characters/%d/", c.ID) + path
+}
@@ -18,6 +18,7 @@ type EVEAPIClient struct {
 	httpClient *http.Client
 	base       EveURI
 	userAgent  string
+	keyInfo    xmlKeyInfoCache
 }
 
 // ErrorMessage format if a CREST query fails.
@@ -25,6 +26,16 @@ type ErrorMessage struct {
 	Message string
 }
 
+// StatusError is returned when a request gets an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return e.Status
+}
+
 // Executes a request generated with newRequest
 func (c *EVEAPIClient) executeRequest(req *http.Request) (*http.Response, error) {
 	res, err := c.httpClient.Do(req)
@@ -33,10 +44,11 @@ func (c *EVEAPIClient) executeRequest(req *http.Request) (*http.Response, error)
 		return nil, err
 	}
 	if res.StatusCode == http.StatusOK ||
-		res.StatusCode == http.StatusCreated {
+		res.StatusCode == http.StatusCreated ||
+		res.StatusCode == http.StatusNoContent {
 		return res, nil
 	} else {
-		return res, errors.New(res.Status)
+		return res, &StatusError{res.StatusCode, res.Status}
 	}
 
 }
@@ -135,13 +147,20 @@ func (c *EVEAPIClient) doJSON(method, urlStr string, body interface{}, v interfa
 		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
+	if res.StatusCode != http.StatusOK &&
+		res.StatusCode != http.StatusCreated &&
+		res.StatusCode != http.StatusNoContent {
 		e := &ErrorMessage{}
 		if err := json.Unmarshal([]byte(buf), e); err != nil {
 			return nil, err
 		}
 		return nil, errors.New(e.Message)
 	}
+
+	// Writes may not return a body.
+	if v == nil || len(buf) == 0 {
+		return res, nil
+	}
 	if err := json.Unmarshal([]byte(buf), v); err != nil {
 		return nil, err
 	}
@@ -46,6 +46,12 @@ type itemReference struct {
 	} `json:"icon"`
 }
 
+// ID, Href & Name references
+type namedReference struct {
+	idHref
+	Name string `json:"name,omitempty"`
+}
+
 type simpleHref struct {
 	Href string `json:"href"`
 }
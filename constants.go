@@ -43,10 +43,14 @@ type EVETime struct {
 // Cannot properly Unmarshal CCP's time stamps?
 const eveTimeLayout = "2006-01-02T15:04:05"
 
+// Times marshal as RFC 3339 through time.Time, so accept that too when reading back.
 func (c *EVETime) UnmarshalJSON(b []byte) (err error) {
 	t := string(b)
 	t = strings.Replace(t, `"`, "", -1)
 	c.Time, err = time.Parse(eveTimeLayout, t)
+	if err != nil {
+		c.Time, err = time.Parse(time.RFC3339, t)
+	}
 	return
 }
 func (c *EVETime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
@@ -85,9 +89,14 @@ func (c *EVEXMLTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err e
 	return
 }
 
+// Empty times, such as the expiry of keys that never expire, are left zero.
 func (c *EVEXMLTime) UnmarshalXMLAttr(a xml.Attr) (err error) {
 	t := a.Value
 	t = strings.Replace(t, `"`, "", -1)
+	if t == "" {
+		c.Time = time.Time{}
+		return nil
+	}
 	c.Time, err = time.Parse(eveXMLTimeLayout, t)
 	return
 }
@@ -99,10 +108,14 @@ type EVEKillmailTime struct {
 // Cannot properly Unmarshal CCP's KillmailTime stamps?
 const eveKillmailTimeLayout = "2006.01.02 15:04:05"
 
+// Times marshal as RFC 3339 through time.Time, so accept that too when reading back.
 func (c *EVEKillmailTime) UnmarshalJSON(b []byte) (err error) {
 	t := string(b)
 	t = strings.Replace(t, `"`, "", -1)
 	c.Time, err = time.Parse(eveKillmailTimeLayout, t)
+	if err != nil {
+		c.Time, err = time.Parse(time.RFC3339, t)
+	}
 	return
 }
 
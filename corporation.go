@@ -1,13 +1,16 @@
 package eveapi
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 // CharacterInfo returned data from XML API
 type CorporationSheetXML struct {
 	xmlAPIFrame
 	CorporationID   int64  `xml:"result>corporationID"`
 	CorporationName string `xml:"result>corporationName"`
-	Ticker          string `xml:"result>ricker"`
+	Ticker          string `xml:"result>ticker"`
 	CEOID           int64  `xml:"result>ceoID"`
 	CEOName         string `xml:"result>ceoName"`
 	StationID       int64  `xml:"result>stationID"`
@@ -20,7 +23,7 @@ type CorporationSheetXML struct {
 	MemberCount     int64  `xml:"result>memberCount"`
 	Shares          int64  `xml:"result>shares"`
 	Logo            struct {
-		GraphicID int64 `xml:"grapicID,attr"`
+		GraphicID int64 `xml:"graphicID,attr"`
 		Shape1    int64 `xml:"shape1,attr"`
 		Shape2    int64 `xml:"shape2,attr"`
 		Shape3    int64 `xml:"shape3,attr"`
@@ -28,6 +31,15 @@ type CorporationSheetXML struct {
 		Color2    int64 `xml:"color2,attr"`
 		Color3    int64 `xml:"color3,attr"`
 	} `xml:"result>logo"`
+
+	// Hangar and wallet division names, only returned by the private sheet.
+	Rowsets []struct {
+		Name string `xml:"name,attr"`
+		Rows []struct {
+			AccountKey  int64  `xml:"accountKey,attr"`
+			Description string `xml:"description,attr"`
+		} `xml:"row"`
+	} `xml:"result>rowset"`
 }
 
 // GetCharacterInfo queries the XML API for a given characterID.
@@ -41,3 +53,38 @@ func (c *EVEAPIClient) CorporationPublicSheetXML(corporationID int64) (*Corporat
 	}
 	return w, nil
 }
+
+// CorporationSheetXML queries the XML API for the private sheet of the character's
+// corporation, which includes the division names.
+func (c *EVEAPIClient) CorporationSheetXML(auth XMLAuth, characterID int64) (*CorporationSheetXML, error) {
+	w := &CorporationSheetXML{}
+
+	params := url.Values{"characterID": {fmt.Sprintf("%d", characterID)}}
+	if err := c.doAuthedXML(auth, xmlCorpSheet, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WalletDivisions returns the wallet division names by accountKey.
+func (s *CorporationSheetXML) WalletDivisions() map[int64]string {
+	return s.divisions("walletDivisions")
+}
+
+// HangarDivisions returns the hangar division names by accountKey.
+func (s *CorporationSheetXML) HangarDivisions() map[int64]string {
+	return s.divisions("divisions")
+}
+
+func (s *CorporationSheetXML) divisions(name string) map[int64]string {
+	d := make(map[int64]string)
+	for _, rowset := range s.Rowsets {
+		if rowset.Name != name {
+			continue
+		}
+		for _, r := range rowset.Rows {
+			d[r.AccountKey] = r.Description
+		}
+	}
+	return d
+}
@@ -0,0 +1,167 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/antihax/eveapi/internal/workers"
+)
+
+// Corporation wallet divisions are numbered by accountKey from the master wallet.
+const (
+	AccountKeyMaster   int64 = 1000
+	walletDivisionsLen       = 7
+)
+
+// CorporationAccountKeys returns the accountKeys of the seven wallet divisions.
+func CorporationAccountKeys() []int64 {
+	keys := make([]int64, walletDivisionsLen)
+	for i := range keys {
+		keys[i] = AccountKeyMaster + int64(i)
+	}
+	return keys
+}
+
+func validAccountKey(accountKey int64) error {
+	if accountKey < AccountKeyMaster || accountKey >= AccountKeyMaster+walletDivisionsLen {
+		return fmt.Errorf("invalid accountKey %d", accountKey)
+	}
+	return nil
+}
+
+// AccountBalanceXML returned data from XML API
+type AccountBalanceXML struct {
+	xmlAPIFrame
+	Accounts []struct {
+		AccountID  int64   `xml:"accountID,attr"`
+		AccountKey int64   `xml:"accountKey,attr"`
+		Balance    float64 `xml:"balance,attr"`
+	} `xml:"result>rowset>row"`
+}
+
+// Balance returns the balance of the division, or zero if it was not returned.
+func (a *AccountBalanceXML) Balance(accountKey int64) float64 {
+	for _, r := range a.Accounts {
+		if r.AccountKey == accountKey {
+			return r.Balance
+		}
+	}
+	return 0
+}
+
+// CorporationAccountBalanceXML queries the XML API for the balances of the wallet
+// divisions of the character's corporation.
+func (c *EVEAPIClient) CorporationAccountBalanceXML(auth XMLAuth, characterID int64) (*AccountBalanceXML, error) {
+	w := &AccountBalanceXML{}
+
+	params := url.Values{"characterID": {fmt.Sprintf("%d", characterID)}}
+	if err := c.doAuthedXML(auth, xmlCorpAccountBalance, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func corporationWalletParams(characterID int64, accountKey int64, fromID int64) url.Values {
+	params := url.Values{
+		"characterID": {fmt.Sprintf("%d", characterID)},
+		"accountKey":  {fmt.Sprintf("%d", accountKey)},
+		"rowCount":    {fmt.Sprintf("%d", walletRowCount)},
+	}
+	if fromID > 0 {
+		params.Set("fromID", fmt.Sprintf("%d", fromID))
+	}
+	return params
+}
+
+// CorporationWalletJournalXML queries the XML API for a page of the journal of a
+// corporation wallet division, walking back from fromID when it is set.
+func (c *EVEAPIClient) CorporationWalletJournalXML(auth XMLAuth, characterID int64, accountKey int64, fromID int64) (*WalletJournalXML, error) {
+	if err := validAccountKey(accountKey); err != nil {
+		return nil, err
+	}
+	w := &WalletJournalXML{}
+
+	params := corporationWalletParams(characterID, accountKey, fromID)
+	if err := c.doAuthedXML(auth, xmlCorpWalletJournal, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CorporationWalletTransactionsXML queries the XML API for a page of the
+// transactions of a corporation wallet division, walking back from fromID when it is set.
+func (c *EVEAPIClient) CorporationWalletTransactionsXML(auth XMLAuth, characterID int64, accountKey int64, fromID int64) (*WalletTransactionXML, error) {
+	if err := validAccountKey(accountKey); err != nil {
+		return nil, err
+	}
+	w := &WalletTransactionXML{}
+
+	params := corporationWalletParams(characterID, accountKey, fromID)
+	if err := c.doAuthedXML(auth, xmlCorpWalletTransactions, characterID, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CorporationWalletJournalAllXML walks the journal of a corporation wallet division
+// back as far as the options allow and returns the entries oldest first.
+func (c *EVEAPIClient) CorporationWalletJournalAllXML(auth XMLAuth, characterID int64, accountKey int64, opts WalletWalkOptions) ([]WalletJournalEntryXML, error) {
+	return walkJournal(func(fromID int64) (*WalletJournalXML, error) {
+		return c.CorporationWalletJournalXML(auth, characterID, accountKey, fromID)
+	}, opts)
+}
+
+// CorporationWalletTransactionsAllXML walks the transactions of a corporation wallet
+// division back as far as the options allow and returns them oldest first.
+func (c *EVEAPIClient) CorporationWalletTransactionsAllXML(auth XMLAuth, characterID int64, accountKey int64, opts WalletWalkOptions) ([]WalletTransactionEntryXML, error) {
+	return walkTransactions(func(fromID int64) (*WalletTransactionXML, error) {
+		return c.CorporationWalletTransactionsXML(auth, characterID, accountKey, fromID)
+	}, opts)
+}
+
+// CorporationWalletJournalDivisionsXML walks the journals of all seven wallet
+// divisions, concurrency at a time, and returns the entries by accountKey.
+func (c *EVEAPIClient) CorporationWalletJournalDivisionsXML(auth XMLAuth, characterID int64, opts WalletWalkOptions, concurrency int) (map[int64][]WalletJournalEntryXML, error) {
+	keys := CorporationAccountKeys()
+	divisions := make(map[int64][]WalletJournalEntryXML)
+	mu := sync.Mutex{}
+
+	err := workers.Run(concurrency, len(keys), func(i int) error {
+		entries, err := c.CorporationWalletJournalAllXML(auth, characterID, keys[i], opts)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		divisions[keys[i]] = entries
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return divisions, nil
+}
+
+// CorporationWalletTransactionsDivisionsXML walks the transactions of all seven
+// wallet divisions, concurrency at a time, and returns them by accountKey.
+func (c *EVEAPIClient) CorporationWalletTransactionsDivisionsXML(auth XMLAuth, characterID int64, opts WalletWalkOptions, concurrency int) (map[int64][]WalletTransactionEntryXML, error) {
+	keys := CorporationAccountKeys()
+	divisions := make(map[int64][]WalletTransactionEntryXML)
+	mu := sync.Mutex{}
+
+	err := workers.Run(concurrency, len(keys), func(i int) error {
+		entries, err := c.CorporationWalletTransactionsAllXML(auth, characterID, keys[i], opts)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		divisions[keys[i]] = entries
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return divisions, nil
+}
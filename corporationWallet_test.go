@@ -0,0 +1,79 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCorporationWalletDivisions(t *testing.T) {
+	mu := sync.Mutex{}
+	requested := make(map[int64]bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("accessType") != "corporation" {
+			t.Errorf("Corporation call %s without accessType", r.URL.Path)
+			http.Error(w, "accessType required", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/corp/CorporationSheet.xml.aspx":
+			fmt.Fprint(w, `<eveapi version="2"><result><corporationID>1</corporationID>
+				<rowset name="divisions"><row accountKey="1000" description="Hangar" /></rowset>
+				<rowset name="walletDivisions"><row accountKey="1000" description="Master Wallet" /><row accountKey="1001" description="Ops" /></rowset>
+				</result></eveapi>`)
+		case "/corp/AccountBalance.xml.aspx":
+			fmt.Fprint(w, `<eveapi version="2"><result><rowset name="accounts">
+				<row accountID="1" accountKey="1000" balance="100.50" /><row accountID="2" accountKey="1001" balance="5" />
+				</rowset></result></eveapi>`)
+		case "/corp/WalletJournal.xml.aspx":
+			key, _ := strconv.ParseInt(r.URL.Query().Get("accountKey"), 10, 64)
+			mu.Lock()
+			requested[key] = true
+			mu.Unlock()
+			fmt.Fprintf(w, `<eveapi version="2"><result><rowset name="entries">
+				<row date="2016-07-01 10:00:00" refID="%d" refTypeID="37" amount="-1" />
+				</rowset></result></eveapi>`, key*10)
+		}
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{XML: srv.URL + "/"}, userAgent: USER_AGENT}
+	auth := NewXMLTokenAuth(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+
+	sheet, err := c.CorporationSheetXML(auth, 1)
+	if err != nil {
+		t.Fatalf("Error getting sheet %v", err)
+	}
+	if d := sheet.WalletDivisions(); len(d) != 2 || d[1001] != "Ops" {
+		t.Errorf("Wrong wallet divisions %v", d)
+	}
+	if d := sheet.HangarDivisions(); len(d) != 1 || d[1000] != "Hangar" {
+		t.Errorf("Wrong hangar divisions %v", d)
+	}
+
+	balance, err := c.CorporationAccountBalanceXML(auth, 1)
+	if err != nil || balance.Balance(1000) != 100.50 || balance.Balance(1006) != 0 {
+		t.Errorf("Wrong balances %v %v", balance, err)
+	}
+
+	divisions, err := c.CorporationWalletJournalDivisionsXML(auth, 1, WalletWalkOptions{}, 3)
+	if err != nil {
+		t.Fatalf("Error walking divisions %v", err)
+	}
+	if len(divisions) != 7 || len(requested) != 7 {
+		t.Fatalf("Expected 7 divisions, got %d", len(divisions))
+	}
+	for key, entries := range divisions {
+		if len(entries) != 1 || entries[0].RefID != key*10 {
+			t.Errorf("Wrong entries for division %d: %v", key, entries)
+		}
+	}
+
+	if _, err := c.CorporationWalletJournalXML(auth, 1, 999, 0); err == nil {
+		t.Errorf("Expected an error for an invalid accountKey")
+	}
+}
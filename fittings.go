@@ -0,0 +1,493 @@
+package eveapi
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Inventory flags used by fittings.
+const (
+	FlagCargo          AssetFlag = 5
+	FlagLoSlot0        AssetFlag = 11
+	FlagMedSlot0       AssetFlag = 19
+	FlagHiSlot0        AssetFlag = 27
+	FlagDroneBay       AssetFlag = 87
+	FlagRigSlot0       AssetFlag = 92
+	FlagSubSystemSlot0 AssetFlag = 125
+	FlagFighterBay     AssetFlag = 158
+)
+
+// Inventory categories kept in their own bays.
+const (
+	categoryDrone   = 18
+	categoryFighter = 87
+)
+
+// Number of slots of each rack.
+const (
+	maxLoSlots        = 8
+	maxMedSlots       = 8
+	maxHiSlots        = 8
+	maxRigSlots       = 3
+	maxSubSystemSlots = 5
+)
+
+// TypeNameResolver resolves inventory type names to typeIDs when importing fittings.
+type TypeNameResolver interface {
+	TypeIDByName(name string) (int64, error)
+}
+
+// TypeCategoryResolver is implemented by TypeNameResolvers that know the category
+// of a type. EFT fittings parsed with one have their drones and fighters placed in
+// the drone and fighter bays, otherwise all items with a quantity go in the cargo.
+type TypeCategoryResolver interface {
+	TypeCategoryID(typeID int64) (int64, error)
+}
+
+type FittingItemV1 struct {
+	Type     namedReference `json:"type"`
+	Flag     AssetFlag      `json:"flag"`
+	Quantity int64          `json:"quantity"`
+}
+
+type FittingV1 struct {
+	FittingID   int64           `json:"fittingID,omitempty"`
+	Href        string          `json:"href,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Ship        namedReference  `json:"ship"`
+	Items       []FittingItemV1 `json:"items"`
+}
+
+// NewFitting creates an empty fitting for the ship typeID.
+func NewFitting(name, description string, shipTypeID int64, shipName string) *FittingV1 {
+	f := &FittingV1{Name: name, Description: description}
+	f.Ship.ID = shipTypeID
+	f.Ship.Name = shipName
+	return f
+}
+
+// AddItem adds quantity of typeID to the fitting at the inventory flag.
+func (f *FittingV1) AddItem(typeID int64, typeName string, flag AssetFlag, quantity int64) {
+	i := FittingItemV1{Flag: flag, Quantity: quantity}
+	i.Type.ID = typeID
+	i.Type.Name = typeName
+	f.Items = append(f.Items, i)
+}
+
+const fittingsCollectionV1Type = "application/vnd.ccp.eve.FittingsCollection-v1"
+const fittingV1Type = "application/vnd.ccp.eve.Fitting-v1"
+
+type FittingsCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	auth oauth2.TokenSource
+
+	Items []FittingV1
+}
+
+// FittingsV1 lists the fittings saved on the character. Requires ScopeCharacterFittingsRead.
+func (c *CharacterV4) FittingsV1(auth oauth2.TokenSource) (*FittingsCollectionV1, error) {
+	return c.EVEAPIClient.fittingsV1(c.href(c.Fittings, "fittings/"), auth)
+}
+
+func (c *EVEAPIClient) fittingsV1(url string, auth oauth2.TokenSource) (*FittingsCollectionV1, error) {
+	w := &FittingsCollectionV1{EVEAPIClient: c, auth: auth}
+	res, err := c.doJSON("GET", url, nil, w, fittingsCollectionV1Type, auth)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(url, res)
+	return w, nil
+}
+
+func (c *FittingsCollectionV1) NextPage() (*FittingsCollectionV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.fittingsV1(c.Next.HRef, c.auth)
+}
+
+// SaveFittingV1 saves a new fitting on the character. Requires ScopeCharacterFittingsWrite.
+// Missing type hrefs are filled in from the typeIDs.
+func (c *CharacterV4) SaveFittingV1(auth oauth2.TokenSource, f *FittingV1) error {
+	if f.Ship.ID == 0 {
+		return errors.New("fitting has no ship type")
+	}
+	c.fillTypeHref(&f.Ship)
+	for i := range f.Items {
+		c.fillTypeHref(&f.Items[i].Type)
+	}
+
+	res, err := c.doJSON("POST", c.href(c.Fittings, "fittings/"), f, nil, fittingV1Type, auth)
+	if err != nil {
+		return err
+	}
+
+	// The new fitting location is returned on creation.
+	if loc := res.Header.Get("Location"); loc != "" {
+		f.Href = loc
+		parts := strings.Split(strings.TrimSuffix(loc, "/"), "/")
+		if id, err := strconv.ParseInt(parts[len(parts)-1], 10, 64); err == nil {
+			f.FittingID = id
+		}
+	}
+	return nil
+}
+
+// DeleteFittingV1 deletes a saved fitting from the character. Requires ScopeCharacterFittingsWrite.
+func (c *CharacterV4) DeleteFittingV1(auth oauth2.TokenSource, fittingID int64) error {
+	url := c.href(c.Fittings, "fittings/") + fmt.Sprintf("%d/", fittingID)
+	_, err := c.doJSON("DELETE", url, nil, nil, fittingV1Type, auth)
+	return err
+}
+
+func (c *EVEAPIClient) fillTypeHref(t *namedReference) {
+	if t.Href == "" {
+		t.Href = c.base.CREST + fmt.Sprintf("inventory/types/%d/", t.ID)
+	}
+}
+
+// Fitting racks in the order EFT lists them.
+type fittingRack struct {
+	eftName  string // name used in EFT empty slot markers
+	xmlName  string // name used in XML slots
+	flag     AssetFlag
+	maxSlots int64
+}
+
+var fittingRacks = []fittingRack{
+	{"low", "low slot", FlagLoSlot0, maxLoSlots},
+	{"med", "med slot", FlagMedSlot0, maxMedSlots},
+	{"high", "hi slot", FlagHiSlot0, maxHiSlots},
+	{"rig", "rig slot", FlagRigSlot0, maxRigSlots},
+	{"subsystem", "subsystem slot", FlagSubSystemSlot0, maxSubSystemSlots},
+}
+
+// Find the rack a flag belongs to.
+func rackForFlag(flag AssetFlag) *fittingRack {
+	for i := range fittingRacks {
+		r := &fittingRacks[i]
+		if flag >= r.flag && flag < r.flag+AssetFlag(r.maxSlots) {
+			return r
+		}
+	}
+	return nil
+}
+
+// EFT converts the fitting to EFT text format.
+func (f *FittingV1) EFT() string {
+	items := make([]FittingItemV1, len(f.Items))
+	copy(items, f.Items)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Flag < items[j].Flag })
+
+	var sections []string
+	for i := range fittingRacks {
+		var lines []string
+		for _, item := range items {
+			if rackForFlag(item.Flag) == &fittingRacks[i] {
+				lines = append(lines, item.Type.Name)
+			}
+		}
+		// Empty racks are still written so the racks after them stay in place.
+		if len(lines) == 0 {
+			name := fittingRacks[i].eftName
+			lines = append(lines, fmt.Sprintf("[Empty %s slot]", strings.ToUpper(name[:1])+name[1:]))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	for _, flag := range []AssetFlag{FlagDroneBay, FlagFighterBay, FlagCargo} {
+		var lines []string
+		for _, item := range items {
+			if item.Flag == flag {
+				lines = append(lines, fmt.Sprintf("%s x%d", item.Type.Name, item.Quantity))
+			}
+		}
+		if len(lines) > 0 {
+			sections = append(sections, strings.Join(lines, "\n"))
+		}
+	}
+
+	return fmt.Sprintf("[%s, %s]\n%s\n", f.Ship.Name, f.Name, strings.Join(sections, "\n\n"))
+}
+
+// ParseEFTFittings parses one or more fittings in EFT text format.
+// Type names are resolved to typeIDs through resolver.
+func ParseEFTFittings(r io.Reader, resolver TypeNameResolver) ([]*FittingV1, error) {
+	var (
+		fittings []*FittingV1
+		f        *FittingV1
+		rack     int   // current rack
+		slot     int64 // next slot in the current rack
+		lines    int   // lines seen in the current section
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Header starts a new fitting.
+		if strings.HasPrefix(line, "[") && !strings.HasPrefix(strings.ToLower(line), "[empty") {
+			header := strings.SplitN(strings.Trim(line, "[]"), ",", 2)
+			if len(header) != 2 {
+				return nil, fmt.Errorf("invalid EFT header %q", line)
+			}
+			shipName := strings.TrimSpace(header[0])
+			shipID, err := resolver.TypeIDByName(shipName)
+			if err != nil {
+				return nil, err
+			}
+			f = NewFitting(strings.TrimSpace(header[1]), "", shipID, shipName)
+			fittings = append(fittings, f)
+			rack, slot, lines = 0, 0, 0
+			continue
+		}
+
+		if f == nil {
+			if line == "" {
+				continue
+			}
+			return nil, fmt.Errorf("EFT item before header: %q", line)
+		}
+
+		// Blank lines separate racks.
+		if line == "" {
+			if lines > 0 {
+				rack++
+				slot = 0
+				lines = 0
+			}
+			continue
+		}
+		lines++
+
+		// Empty slot markers name their rack.
+		if strings.HasPrefix(strings.ToLower(line), "[empty") {
+			name := strings.ToLower(strings.Trim(line, "[]"))
+			for i := range fittingRacks {
+				if strings.HasPrefix(name, "empty "+fittingRacks[i].eftName) {
+					rack = i
+				}
+			}
+			slot++
+			continue
+		}
+
+		// Items with a quantity belong in a bay or the cargo.
+		if name, qty, ok := splitEFTQuantity(line); ok {
+			typeID, err := resolver.TypeIDByName(name)
+			if err != nil {
+				return nil, err
+			}
+			flag, err := bayForType(resolver, typeID)
+			if err != nil {
+				return nil, err
+			}
+			f.AddItem(typeID, name, flag, qty)
+			continue
+		}
+
+		if rack >= len(fittingRacks) {
+			return nil, fmt.Errorf("too many EFT sections at %q", line)
+		}
+
+		// Strip offline markers and loaded charges.
+		line = strings.TrimSpace(strings.TrimSuffix(line, "/OFFLINE"))
+		var charge string
+		if parts := strings.SplitN(line, ",", 2); len(parts) == 2 {
+			line = strings.TrimSpace(parts[0])
+			charge = strings.TrimSpace(parts[1])
+		}
+
+		r := fittingRacks[rack]
+		if slot >= r.maxSlots {
+			return nil, fmt.Errorf("too many modules in %s rack", r.eftName)
+		}
+		typeID, err := resolver.TypeIDByName(line)
+		if err != nil {
+			return nil, err
+		}
+		f.AddItem(typeID, line, r.flag+AssetFlag(slot), 1)
+		slot++
+
+		if charge != "" {
+			chargeID, err := resolver.TypeIDByName(charge)
+			if err != nil {
+				return nil, err
+			}
+			f.addCargo(chargeID, charge, 1)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return fittings, nil
+}
+
+// Find the bay of an item from its category, or the cargo if it is not known.
+func bayForType(resolver TypeNameResolver, typeID int64) (AssetFlag, error) {
+	categories, ok := resolver.(TypeCategoryResolver)
+	if !ok {
+		return FlagCargo, nil
+	}
+	categoryID, err := categories.TypeCategoryID(typeID)
+	if err != nil {
+		return 0, err
+	}
+	switch categoryID {
+	case categoryDrone:
+		return FlagDroneBay, nil
+	case categoryFighter:
+		return FlagFighterBay, nil
+	}
+	return FlagCargo, nil
+}
+
+// Split "Name xN" into name and quantity.
+func splitEFTQuantity(line string) (string, int64, bool) {
+	i := strings.LastIndex(line, " x")
+	if i < 0 {
+		return "", 0, false
+	}
+	qty, err := strconv.ParseInt(line[i+2:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return strings.TrimSpace(line[:i]), qty, true
+}
+
+// Add to an existing cargo stack or create one.
+func (f *FittingV1) addCargo(typeID int64, typeName string, quantity int64) {
+	for i := range f.Items {
+		if f.Items[i].Flag == FlagCargo && f.Items[i].Type.ID == typeID {
+			f.Items[i].Quantity += quantity
+			return
+		}
+	}
+	f.AddItem(typeID, typeName, FlagCargo, quantity)
+}
+
+// In game XML fitting format
+type fittingsXML struct {
+	XMLName  xml.Name     `xml:"fittings"`
+	Fittings []fittingXML `xml:"fitting"`
+}
+
+type fittingXML struct {
+	Name        string `xml:"name,attr"`
+	Description struct {
+		Value string `xml:"value,attr"`
+	} `xml:"description"`
+	ShipType struct {
+		Value string `xml:"value,attr"`
+	} `xml:"shipType"`
+	Hardware []fittingHardwareXML `xml:"hardware"`
+}
+
+type fittingHardwareXML struct {
+	Quantity int64  `xml:"qty,attr,omitempty"`
+	Slot     string `xml:"slot,attr"`
+	Type     string `xml:"type,attr"`
+}
+
+var fittingBayNames = map[AssetFlag]string{
+	FlagCargo:      "cargo",
+	FlagDroneBay:   "drone bay",
+	FlagFighterBay: "fighter bay",
+}
+
+// WriteXMLFittings writes fittings in the in game XML fitting format.
+func WriteXMLFittings(w io.Writer, fittings ...*FittingV1) error {
+	x := fittingsXML{}
+	for _, f := range fittings {
+		fx := fittingXML{Name: f.Name}
+		fx.Description.Value = f.Description
+		fx.ShipType.Value = f.Ship.Name
+		for _, item := range f.Items {
+			h := fittingHardwareXML{Type: item.Type.Name}
+			if r := rackForFlag(item.Flag); r != nil {
+				h.Slot = fmt.Sprintf("%s %d", r.xmlName, item.Flag-r.flag)
+			} else if name, ok := fittingBayNames[item.Flag]; ok {
+				h.Slot = name
+				h.Quantity = item.Quantity
+			} else {
+				return fmt.Errorf("unsupported fitting flag %d", item.Flag)
+			}
+			fx.Hardware = append(fx.Hardware, h)
+		}
+		x.Fittings = append(x.Fittings, fx)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return e.Encode(x)
+}
+
+// ParseXMLFittings parses fittings in the in game XML fitting format.
+// Type names are resolved to typeIDs through resolver.
+func ParseXMLFittings(r io.Reader, resolver TypeNameResolver) ([]*FittingV1, error) {
+	x := fittingsXML{}
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, err
+	}
+
+	var fittings []*FittingV1
+	for _, fx := range x.Fittings {
+		shipID, err := resolver.TypeIDByName(fx.ShipType.Value)
+		if err != nil {
+			return nil, err
+		}
+		f := NewFitting(fx.Name, fx.Description.Value, shipID, fx.ShipType.Value)
+
+		for _, h := range fx.Hardware {
+			flag, err := parseXMLFittingSlot(h.Slot)
+			if err != nil {
+				return nil, err
+			}
+			typeID, err := resolver.TypeIDByName(h.Type)
+			if err != nil {
+				return nil, err
+			}
+			qty := h.Quantity
+			if qty == 0 {
+				qty = 1
+			}
+			f.AddItem(typeID, h.Type, flag, qty)
+		}
+		fittings = append(fittings, f)
+	}
+	return fittings, nil
+}
+
+// Convert an XML slot name to an inventory flag.
+func parseXMLFittingSlot(slot string) (AssetFlag, error) {
+	slot = strings.ToLower(strings.TrimSpace(slot))
+	for flag, name := range fittingBayNames {
+		if slot == name {
+			return flag, nil
+		}
+	}
+	for _, r := range fittingRacks {
+		if strings.HasPrefix(slot, r.xmlName+" ") {
+			n, err := strconv.ParseInt(strings.TrimPrefix(slot, r.xmlName+" "), 10, 64)
+			if err != nil || n < 0 || n >= r.maxSlots {
+				return 0, fmt.Errorf("invalid fitting slot %q", slot)
+			}
+			return r.flag + AssetFlag(n), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown fitting slot %q", slot)
+}
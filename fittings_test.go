@@ -0,0 +1,181 @@
+package eveapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testTypeNames map[string]int64
+
+func (t testTypeNames) TypeIDByName(name string) (int64, error) {
+	if id, ok := t[name]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown type %s", name)
+}
+
+// Categories of the test types that are not modules.
+var testFittingCategories = map[int64]int64{
+	2456:  categoryDrone,
+	40362: categoryFighter,
+}
+
+func (t testTypeNames) TypeCategoryID(typeID int64) (int64, error) {
+	return testFittingCategories[typeID], nil
+}
+
+var testFittingTypes = testTypeNames{
+	"Rifter":                           587,
+	"Gyrostabilizer II":                519,
+	"Damage Control II":                2048,
+	"1MN Afterburner II":               438,
+	"Warp Scrambler II":                448,
+	"200mm AutoCannon II":              2873,
+	"EMP S":                            185,
+	"Small Projectile Burst Aerator I": 31538,
+	"Hobgoblin II":                     2456,
+	"Nanite Repair Paste":              28668,
+	"Templar II":                       40362,
+}
+
+const testEFT = `[Rifter, PvP Rifter]
+Gyrostabilizer II
+Damage Control II
+[Empty Low slot]
+
+1MN Afterburner II
+Warp Scrambler II
+
+200mm AutoCannon II, EMP S
+200mm AutoCannon II, EMP S /OFFLINE
+
+Small Projectile Burst Aerator I
+
+Hobgoblin II x2
+
+Nanite Repair Paste x50
+`
+
+func TestParseEFTFittings(t *testing.T) {
+	fits, err := ParseEFTFittings(strings.NewReader(testEFT), testFittingTypes)
+	if err != nil {
+		t.Fatalf("Error parsing EFT %v", err)
+	}
+	if len(fits) != 1 {
+		t.Fatalf("Expected 1 fitting, got %d", len(fits))
+	}
+	f := fits[0]
+	if f.Ship.ID != 587 || f.Name != "PvP Rifter" {
+		t.Errorf("Header parsed incorrectly %+v", f.Ship)
+	}
+
+	flags := map[AssetFlag]int64{}
+	for _, item := range f.Items {
+		flags[item.Flag] = item.Type.ID
+	}
+	expected := map[AssetFlag]int64{
+		FlagLoSlot0:      519,
+		FlagLoSlot0 + 1:  2048,
+		FlagMedSlot0:     438,
+		FlagMedSlot0 + 1: 448,
+		FlagHiSlot0:      2873,
+		FlagHiSlot0 + 1:  2873,
+		FlagRigSlot0:     31538,
+		FlagDroneBay:     2456,
+	}
+	for flag, typeID := range expected {
+		if flags[flag] != typeID {
+			t.Errorf("Flag %d has type %d, expected %d", flag, flags[flag], typeID)
+		}
+	}
+
+	var charges, paste int64
+	for _, item := range f.Items {
+		if item.Flag == FlagCargo && item.Type.ID == 185 {
+			charges = item.Quantity
+		}
+		if item.Flag == FlagCargo && item.Type.ID == 28668 {
+			paste = item.Quantity
+		}
+	}
+	if charges != 2 || paste != 50 {
+		t.Errorf("Cargo parsed incorrectly charges %d paste %d", charges, paste)
+	}
+}
+
+func TestFittingRoundTrip(t *testing.T) {
+	fits, err := ParseEFTFittings(strings.NewReader(testEFT), testFittingTypes)
+	if err != nil {
+		t.Fatalf("Error parsing EFT %v", err)
+	}
+
+	// EFT back to EFT
+	again, err := ParseEFTFittings(strings.NewReader(fits[0].EFT()), testFittingTypes)
+	if err != nil {
+		t.Fatalf("Error parsing exported EFT %v", err)
+	}
+	compareFittingItems(t, again[0], fits[0])
+
+	// EFT to XML and back
+	buf := &bytes.Buffer{}
+	if err := WriteXMLFittings(buf, fits...); err != nil {
+		t.Fatalf("Error writing XML %v", err)
+	}
+	xmlFits, err := ParseXMLFittings(buf, testFittingTypes)
+	if err != nil {
+		t.Fatalf("Error parsing XML %v", err)
+	}
+	if len(xmlFits) != 1 || len(xmlFits[0].Items) != len(fits[0].Items) {
+		t.Fatalf("XML round trip changed items")
+	}
+	for i, item := range xmlFits[0].Items {
+		if item != fits[0].Items[i] {
+			t.Errorf("XML round trip changed item %+v != %+v", item, fits[0].Items[i])
+		}
+	}
+}
+
+func TestEFTRoundTripEmptyRacks(t *testing.T) {
+	lowAndHigh := NewFitting("Low and high", "", 587, "Rifter")
+	lowAndHigh.AddItem(519, "Gyrostabilizer II", FlagLoSlot0, 1)
+	lowAndHigh.AddItem(2873, "200mm AutoCannon II", FlagHiSlot0, 1)
+
+	cargoOnly := NewFitting("Cargo only", "", 587, "Rifter")
+	cargoOnly.AddItem(28668, "Nanite Repair Paste", FlagCargo, 50)
+
+	bays := NewFitting("Bays", "", 587, "Rifter")
+	bays.AddItem(40362, "Templar II", FlagFighterBay, 3)
+	bays.AddItem(28668, "Nanite Repair Paste", FlagCargo, 10)
+	bays.AddItem(2456, "Hobgoblin II", FlagDroneBay, 5)
+
+	for _, f := range []*FittingV1{lowAndHigh, cargoOnly, bays} {
+		again, err := ParseEFTFittings(strings.NewReader(f.EFT()), testFittingTypes)
+		if err != nil {
+			t.Fatalf("Error parsing exported EFT %v", err)
+		}
+		compareFittingItems(t, again[0], f)
+	}
+}
+
+// Compare the types, flags and quantities of two fittings in any order.
+func compareFittingItems(t *testing.T, got, want *FittingV1) {
+	count := func(f *FittingV1) map[[2]int64]int64 {
+		items := make(map[[2]int64]int64)
+		for _, item := range f.Items {
+			items[[2]int64{item.Type.ID, int64(item.Flag)}] += item.Quantity
+		}
+		return items
+	}
+	gotItems, wantItems := count(got), count(want)
+	if len(gotItems) != len(wantItems) {
+		t.Errorf("%s: round trip changed items %v != %v", want.Name, gotItems, wantItems)
+		return
+	}
+	for k, qty := range wantItems {
+		if gotItems[k] != qty {
+			t.Errorf("%s: type %d at flag %d has quantity %d, expected %d", want.Name, k[0], k[1], gotItems[k], qty)
+		}
+	}
+}
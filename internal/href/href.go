@@ -0,0 +1,15 @@
+// Package href reads IDs out of CREST hrefs.
+package href
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ID returns the last path element of a CREST href as an ID, or zero if it is
+// not a number.
+func ID(href string) int64 {
+	parts := strings.Split(strings.TrimRight(href, "/"), "/")
+	id, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	return id
+}
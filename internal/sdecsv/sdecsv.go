@@ -0,0 +1,158 @@
+// Package sdecsv reads and writes the CSV conversion of the static data export.
+package sdecsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Row reads the fields of a record by column name, keeping the first error.
+// Column names are matched case-insensitively.
+type Row struct {
+	columns map[string]int
+	record  []string
+	err     error
+}
+
+func (r *Row) String(name string) string {
+	i, ok := r.columns[strings.ToLower(name)]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return r.record[i]
+}
+
+func (r *Row) Int(name string) int64 {
+	s := r.String(name)
+	if s == "" || s == "None" {
+		return 0
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("column %s: %v", name, err)
+	}
+	return v
+}
+
+func (r *Row) Float(name string) float64 {
+	s := r.String(name)
+	if s == "" || s == "None" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("column %s: %v", name, err)
+	}
+	return v
+}
+
+// Bool reads 0/1 and true/false columns.
+func (r *Row) Bool(name string) bool {
+	s := r.String(name)
+	if s == "" || s == "None" {
+		return false
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("column %s: %v", name, err)
+	}
+	return v
+}
+
+// Read calls fn for each record after checking the header has the required columns.
+func Read(in io.Reader, required []string, fn func(*Row)) error {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return err
+	}
+	row := &Row{columns: make(map[string]int)}
+	for i, name := range header {
+		row.columns[strings.ToLower(name)] = i
+	}
+	for _, name := range required {
+		if _, ok := row.columns[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("missing column %s", name)
+		}
+	}
+
+	for line := 2; ; line++ {
+		row.record, err = r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(row)
+		if row.err != nil {
+			return fmt.Errorf("line %d: %v", line, row.err)
+		}
+	}
+}
+
+// Write writes the header and records.
+func Write(out io.Writer, header []string, records [][]string) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// LoadFile opens a file for load, naming the file in any error.
+func LoadFile(name string, load func(io.Reader) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := load(f); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// SaveFile creates a file for save.
+func SaveFile(name string, save func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func FormatInt(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func FormatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func FormatBool(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+// SortIDs sorts ids in place and returns them.
+func SortIDs(ids []int64) []int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,55 @@
+// Package workers runs indexed jobs on a pool of goroutines.
+package workers
+
+import "sync"
+
+// Run calls fn for 0 to n-1 from a pool of workers and returns the first error.
+// Jobs stop being handed out after the first error.
+func Run(workers int, n int, fn func(int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	errs := make(chan error, workers)
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := fn(i); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var err error
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case err = <-errs:
+			break feed
+		case <-done:
+			break feed
+		}
+	}
+	close(jobs)
+	<-done
+	if err == nil {
+		select {
+		case err = <-errs:
+		default:
+		}
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package eveapi
+
+import "fmt"
+
+const (
+	itemTypesCollectionV1Type      = "application/vnd.ccp.eve.ItemTypeCollection-v1"
+	itemTypeV3Type                 = "application/vnd.ccp.eve.ItemType-v3"
+	itemGroupsCollectionV1Type     = "application/vnd.ccp.eve.ItemGroupCollection-v1"
+	itemGroupV1Type                = "application/vnd.ccp.eve.ItemGroup-v1"
+	itemCategoriesCollectionV1Type = "application/vnd.ccp.eve.ItemCategoryCollection-v1"
+	itemCategoryV1Type             = "application/vnd.ccp.eve.ItemCategory-v1"
+	marketGroupsCollectionV1Type   = "application/vnd.ccp.eve.MarketGroupCollection-v1"
+	marketTypesCollectionV1Type    = "application/vnd.ccp.eve.MarketTypeCollection-v1"
+)
+
+type ItemTypesCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []namedReference
+}
+
+func (c *EVEAPIClient) ItemTypesV1(href string) (*ItemTypesCollectionV1, error) {
+	w := &ItemTypesCollectionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, itemTypesCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(href, res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ItemTypesV1ByPage(page int) (*ItemTypesCollectionV1, error) {
+	return c.ItemTypesV1(c.base.CREST + fmt.Sprintf("inventory/types/?page=%d", page))
+}
+
+func (c *ItemTypesCollectionV1) NextPage() (*ItemTypesCollectionV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.ItemTypesV1(c.Next.HRef)
+}
+
+func (c *ItemTypesCollectionV1) PreviousPage() (*ItemTypesCollectionV1, error) {
+	if c.Previous.HRef == "" {
+		return nil, nil
+	}
+	return c.ItemTypesV1(c.Previous.HRef)
+}
+
+type ItemTypeV3 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	ID          int64
+	Name        string
+	Description string
+	Published   bool
+	Mass        float64
+	Volume      float64
+	Capacity    float64
+	Radius      float64
+	PortionSize int64
+}
+
+func (c *EVEAPIClient) ItemTypeV3(href string) (*ItemTypeV3, error) {
+	w := &ItemTypeV3{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, itemTypeV3Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ItemTypeV3ByID(typeID int64) (*ItemTypeV3, error) {
+	return c.ItemTypeV3(c.base.CREST + fmt.Sprintf("inventory/types/%d/", typeID))
+}
+
+type ItemGroupsCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []namedReference
+}
+
+func (c *EVEAPIClient) ItemGroupsV1(href string) (*ItemGroupsCollectionV1, error) {
+	w := &ItemGroupsCollectionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, itemGroupsCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(href, res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ItemGroupsV1ByPage(page int) (*ItemGroupsCollectionV1, error) {
+	return c.ItemGroupsV1(c.base.CREST + fmt.Sprintf("inventory/groups/?page=%d", page))
+}
+
+func (c *ItemGroupsCollectionV1) NextPage() (*ItemGroupsCollectionV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.ItemGroupsV1(c.Next.HRef)
+}
+
+func (c *ItemGroupsCollectionV1) PreviousPage() (*ItemGroupsCollectionV1, error) {
+	if c.Previous.HRef == "" {
+		return nil, nil
+	}
+	return c.ItemGroupsV1(c.Previous.HRef)
+}
+
+type ItemGroupV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	Name        string
+	Description string
+	Published   bool
+	Category    simpleHref
+	Types       []namedReference
+}
+
+func (c *EVEAPIClient) ItemGroupV1(href string) (*ItemGroupV1, error) {
+	w := &ItemGroupV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, itemGroupV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ItemGroupV1ByID(groupID int64) (*ItemGroupV1, error) {
+	return c.ItemGroupV1(c.base.CREST + fmt.Sprintf("inventory/groups/%d/", groupID))
+}
+
+type ItemCategoriesCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []namedReference
+}
+
+func (c *EVEAPIClient) ItemCategoriesV1() (*ItemCategoriesCollectionV1, error) {
+	w := &ItemCategoriesCollectionV1{EVEAPIClient: c}
+	url := c.base.CREST + "inventory/categories/"
+	res, err := c.doJSON("GET", url, nil, w, itemCategoriesCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(url, res)
+	return w, nil
+}
+
+type ItemCategoryV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	Name      string
+	Published bool
+	Groups    []namedReference
+}
+
+func (c *EVEAPIClient) ItemCategoryV1(href string) (*ItemCategoryV1, error) {
+	w := &ItemCategoryV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, itemCategoryV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ItemCategoryV1ByID(categoryID int64) (*ItemCategoryV1, error) {
+	return c.ItemCategoryV1(c.base.CREST + fmt.Sprintf("inventory/categories/%d/", categoryID))
+}
+
+type MarketGroupsCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []struct {
+		idHref
+		Name        string
+		Description string
+		ParentGroup simpleHref
+		Types       simpleHref
+	}
+}
+
+func (c *EVEAPIClient) MarketGroupsV1(href string) (*MarketGroupsCollectionV1, error) {
+	w := &MarketGroupsCollectionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, marketGroupsCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(href, res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) MarketGroupsV1ByPage(page int) (*MarketGroupsCollectionV1, error) {
+	return c.MarketGroupsV1(c.base.CREST + fmt.Sprintf("market/groups/?page=%d", page))
+}
+
+func (c *MarketGroupsCollectionV1) NextPage() (*MarketGroupsCollectionV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.MarketGroupsV1(c.Next.HRef)
+}
+
+func (c *MarketGroupsCollectionV1) PreviousPage() (*MarketGroupsCollectionV1, error) {
+	if c.Previous.HRef == "" {
+		return nil, nil
+	}
+	return c.MarketGroupsV1(c.Previous.HRef)
+}
+
+type MarketTypesCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []struct {
+		MarketGroup idHref
+		Type        itemReference
+	}
+}
+
+func (c *EVEAPIClient) MarketTypesV1(href string) (*MarketTypesCollectionV1, error) {
+	w := &MarketTypesCollectionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, marketTypesCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(href, res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) MarketTypesV1ByPage(page int) (*MarketTypesCollectionV1, error) {
+	return c.MarketTypesV1(c.base.CREST + fmt.Sprintf("market/types/?page=%d", page))
+}
+
+func (c *MarketTypesCollectionV1) NextPage() (*MarketTypesCollectionV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.MarketTypesV1(c.Next.HRef)
+}
+
+func (c *MarketTypesCollectionV1) PreviousPage() (*MarketTypesCollectionV1, error) {
+	if c.Previous.HRef == "" {
+		return nil, nil
+	}
+	return c.MarketTypesV1(c.Previous.HRef)
+}
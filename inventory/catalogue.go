@@ -0,0 +1,211 @@
+// Package inventory holds the catalogue of inventory types with their groups,
+// categories, market groups and volumes.
+//
+// The catalogue is loaded from the Fuzzwork CSV conversion of the static data
+// export, or snapshotted from CREST and saved in the same format.
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antihax/eveapi"
+)
+
+type Type struct {
+	ID            int64
+	GroupID       int64
+	MarketGroupID int64
+	Name          string
+	// Volume is the assembled volume. PackagedVolume is only set for types that
+	// package smaller, such as ships.
+	Volume         float64
+	PackagedVolume float64
+	BasePrice      float64
+	PortionSize    int64
+	Published      bool
+}
+
+type Group struct {
+	ID         int64
+	CategoryID int64
+	Name       string
+	Published  bool
+}
+
+type Category struct {
+	ID        int64
+	Name      string
+	Published bool
+}
+
+type MarketGroup struct {
+	ID       int64
+	ParentID int64
+	Name     string
+}
+
+// Catalogue is the inventory types indexed by ID and by case-insensitive name.
+type Catalogue struct {
+	Types        map[int64]*Type
+	Groups       map[int64]*Group
+	Categories   map[int64]*Category
+	MarketGroups map[int64]*MarketGroup
+
+	typeNames map[string]int64
+}
+
+var (
+	_ eveapi.TypeNameResolver     = (*Catalogue)(nil)
+	_ eveapi.TypeCategoryResolver = (*Catalogue)(nil)
+)
+
+func New() *Catalogue {
+	return &Catalogue{
+		Types:        make(map[int64]*Type),
+		Groups:       make(map[int64]*Group),
+		Categories:   make(map[int64]*Category),
+		MarketGroups: make(map[int64]*MarketGroup),
+		typeNames:    make(map[string]int64),
+	}
+}
+
+func (c *Catalogue) AddType(t Type) {
+	c.Types[t.ID] = &t
+	c.typeNames[strings.ToLower(t.Name)] = t.ID
+}
+
+func (c *Catalogue) AddGroup(g Group) {
+	c.Groups[g.ID] = &g
+}
+
+func (c *Catalogue) AddCategory(cat Category) {
+	c.Categories[cat.ID] = &cat
+}
+
+func (c *Catalogue) AddMarketGroup(m MarketGroup) {
+	c.MarketGroups[m.ID] = &m
+}
+
+// typ returns the type, adding an empty one if it is not known yet.
+func (c *Catalogue) typ(typeID int64) *Type {
+	t := c.Types[typeID]
+	if t == nil {
+		t = &Type{ID: typeID}
+		c.Types[typeID] = t
+	}
+	return t
+}
+
+func (c *Catalogue) TypeByName(name string) (*Type, bool) {
+	t, ok := c.Types[c.typeNames[strings.ToLower(name)]]
+	return t, ok
+}
+
+// TypeIDByName resolves a type name, ignoring case.
+func (c *Catalogue) TypeIDByName(name string) (int64, error) {
+	t, ok := c.TypeByName(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown type %q", name)
+	}
+	return t.ID, nil
+}
+
+// Group returns the group of a type.
+func (c *Catalogue) Group(typeID int64) (*Group, bool) {
+	t, ok := c.Types[typeID]
+	if !ok {
+		return nil, false
+	}
+	g, ok := c.Groups[t.GroupID]
+	return g, ok
+}
+
+// Category returns the category of a type.
+func (c *Catalogue) Category(typeID int64) (*Category, bool) {
+	g, ok := c.Group(typeID)
+	if !ok {
+		return nil, false
+	}
+	cat, ok := c.Categories[g.CategoryID]
+	return cat, ok
+}
+
+// TypeCategoryID returns the categoryID of a type, placing drones and fighters in
+// their bays when parsing EFT fittings.
+func (c *Catalogue) TypeCategoryID(typeID int64) (int64, error) {
+	g, ok := c.Group(typeID)
+	if !ok {
+		return 0, fmt.Errorf("unknown type %d", typeID)
+	}
+	return g.CategoryID, nil
+}
+
+// MarketGroupPath returns the market groups of a type from the root group down.
+func (c *Catalogue) MarketGroupPath(typeID int64) []*MarketGroup {
+	t, ok := c.Types[typeID]
+	if !ok {
+		return nil
+	}
+	var path []*MarketGroup
+	seen := make(map[int64]bool)
+	for id := t.MarketGroupID; id != 0 && !seen[id]; {
+		m, ok := c.MarketGroups[id]
+		if !ok {
+			break
+		}
+		seen[id] = true
+		path = append([]*MarketGroup{m}, path...)
+		id = m.ParentID
+	}
+	return path
+}
+
+// TypesInGroup returns the types of a group ordered by ID.
+func (c *Catalogue) TypesInGroup(groupID int64) []*Type {
+	var types []*Type
+	for _, t := range c.Types {
+		if t.GroupID == groupID {
+			types = append(types, t)
+		}
+	}
+	sortTypes(types)
+	return types
+}
+
+// TypesInMarketGroup returns the types in a market group and all of its children,
+// ordered by ID.
+func (c *Catalogue) TypesInMarketGroup(marketGroupID int64) []*Type {
+	var types []*Type
+	for _, t := range c.Types {
+		if t.MarketGroupID == 0 {
+			continue
+		}
+		for _, m := range c.MarketGroupPath(t.ID) {
+			if m.ID == marketGroupID {
+				types = append(types, t)
+				break
+			}
+		}
+	}
+	sortTypes(types)
+	return types
+}
+
+// ShippingVolume returns the volume of one unit of a type as hauled: the packaged
+// volume if it has one, otherwise its volume.
+func (c *Catalogue) ShippingVolume(typeID int64) (float64, bool) {
+	t, ok := c.Types[typeID]
+	if !ok {
+		return 0, false
+	}
+	if t.PackagedVolume > 0 {
+		return t.PackagedVolume, true
+	}
+	return t.Volume, true
+}
+
+func sortTypes(types []*Type) {
+	sort.Slice(types, func(i, j int) bool { return types[i].ID < types[j].ID })
+}
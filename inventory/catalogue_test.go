@@ -0,0 +1,93 @@
+package inventory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Trimmed rows of the Fuzzwork conversion, with their extra columns.
+var testFiles = map[string]string{
+	TypesFile: `typeID,groupID,typeName,description,mass,volume,capacity,portionSize,raceID,basePrice,published,marketGroupID,iconID,soundID,graphicID
+34,18,Tritanium,"The main building block",0,0.01,0,1,None,2,1,1857,22,None,None
+587,25,Rifter,"A fast frigate",1067000,27289,140,1,2,None,1,64,None,20070,46
+`,
+	GroupsFile: `groupID,categoryID,groupName,iconID,useBasePrice,anchored,anchorable,fittableNonSingleton,published
+18,4,Mineral,22,1,0,0,0,1
+25,6,Frigate,None,0,0,0,0,1
+`,
+	CategoriesFile: `categoryID,categoryName,iconID,published
+4,Material,22,1
+6,Ship,None,1
+`,
+	MarketGroupsFile: `marketGroupID,parentGroupID,marketGroupName,description,iconID,hasTypes
+4,None,Ships,"Capsule-capable vessels",1443,0
+1361,4,Frigates,"Small, fast vessels",1443,0
+64,1361,Minmatar,"Minmatar frigates",1443,1
+1857,None,Minerals,"Raw materials",22,1
+`,
+	VolumesFile: `typeid,volume
+587,2500
+`,
+}
+
+func TestCatalogueLoadSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, data := range testFiles {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Error loading catalogue %v", err)
+	}
+
+	// Save and reload in our own format.
+	saved, err := ioutil.TempDir("", "inventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(saved)
+	if err := c.Save(saved); err != nil {
+		t.Fatalf("Error saving catalogue %v", err)
+	}
+	if c, err = Load(saved); err != nil {
+		t.Fatalf("Error reloading catalogue %v", err)
+	}
+
+	id, err := c.TypeIDByName("rifter")
+	if err != nil || id != 587 {
+		t.Fatalf("Expected Rifter, got %d %v", id, err)
+	}
+	if _, err := c.TypeIDByName("Rifter II"); err == nil {
+		t.Errorf("Unknown type resolved")
+	}
+	if cat, ok := c.Category(id); !ok || cat.Name != "Ship" {
+		t.Errorf("Wrong category %v", cat)
+	}
+
+	path := c.MarketGroupPath(id)
+	if len(path) != 3 || path[0].Name != "Ships" || path[2].Name != "Minmatar" {
+		t.Errorf("Wrong market group path %v", path)
+	}
+	if types := c.TypesInMarketGroup(4); len(types) != 1 || types[0].ID != 587 {
+		t.Errorf("Wrong types in market group %v", types)
+	}
+
+	if v, _ := c.ShippingVolume(587); v != 2500 {
+		t.Errorf("Expected packaged volume, got %f", v)
+	}
+	if v, _ := c.ShippingVolume(34); v != 0.01 {
+		t.Errorf("Expected volume, got %f", v)
+	}
+	if tr := c.Types[34]; tr.BasePrice != 2 || !tr.Published {
+		t.Errorf("Wrong type %v", tr)
+	}
+}
@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/antihax/eveapi/internal/sdecsv"
+)
+
+// Static data export tables, named as in the Fuzzwork CSV conversion.
+const (
+	TypesFile        = "invTypes.csv"
+	GroupsFile       = "invGroups.csv"
+	CategoriesFile   = "invCategories.csv"
+	MarketGroupsFile = "invMarketGroups.csv"
+	VolumesFile      = "invVolumes.csv"
+)
+
+var (
+	typeColumns        = []string{"typeID", "groupID", "typeName", "volume", "portionSize", "basePrice", "published", "marketGroupID"}
+	groupColumns       = []string{"groupID", "categoryID", "groupName", "published"}
+	categoryColumns    = []string{"categoryID", "categoryName", "published"}
+	marketGroupColumns = []string{"marketGroupID", "parentGroupID", "marketGroupName"}
+	volumeColumns      = []string{"typeID", "volume"}
+)
+
+// Load reads the catalogue from the CSV files in dir. Columns are found by header
+// name so full exports with extra columns load as well.
+func Load(dir string) (*Catalogue, error) {
+	c := New()
+	for _, t := range []struct {
+		file string
+		load func(io.Reader) error
+	}{
+		{CategoriesFile, c.readCategories},
+		{GroupsFile, c.readGroups},
+		{MarketGroupsFile, c.readMarketGroups},
+		{TypesFile, c.readTypes},
+		{VolumesFile, c.readVolumes},
+	} {
+		if err := sdecsv.LoadFile(filepath.Join(dir, t.file), t.load); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Save writes the catalogue as CSV files in dir, readable by Load.
+func (c *Catalogue) Save(dir string) error {
+	for _, t := range []struct {
+		file string
+		save func(io.Writer) error
+	}{
+		{CategoriesFile, c.writeCategories},
+		{GroupsFile, c.writeGroups},
+		{MarketGroupsFile, c.writeMarketGroups},
+		{TypesFile, c.writeTypes},
+		{VolumesFile, c.writeVolumes},
+	} {
+		if err := sdecsv.SaveFile(filepath.Join(dir, t.file), t.save); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Catalogue) readTypes(in io.Reader) error {
+	return sdecsv.Read(in, []string{"typeID", "groupID", "typeName"}, func(r *sdecsv.Row) {
+		c.AddType(Type{
+			ID:            r.Int("typeID"),
+			GroupID:       r.Int("groupID"),
+			MarketGroupID: r.Int("marketGroupID"),
+			Name:          r.String("typeName"),
+			Volume:        r.Float("volume"),
+			BasePrice:     r.Float("basePrice"),
+			PortionSize:   r.Int("portionSize"),
+			Published:     r.Bool("published"),
+		})
+	})
+}
+
+func (c *Catalogue) readGroups(in io.Reader) error {
+	return sdecsv.Read(in, []string{"groupID", "categoryID", "groupName"}, func(r *sdecsv.Row) {
+		c.AddGroup(Group{
+			ID:         r.Int("groupID"),
+			CategoryID: r.Int("categoryID"),
+			Name:       r.String("groupName"),
+			Published:  r.Bool("published"),
+		})
+	})
+}
+
+func (c *Catalogue) readCategories(in io.Reader) error {
+	return sdecsv.Read(in, []string{"categoryID", "categoryName"}, func(r *sdecsv.Row) {
+		c.AddCategory(Category{
+			ID:        r.Int("categoryID"),
+			Name:      r.String("categoryName"),
+			Published: r.Bool("published"),
+		})
+	})
+}
+
+func (c *Catalogue) readMarketGroups(in io.Reader) error {
+	return sdecsv.Read(in, marketGroupColumns, func(r *sdecsv.Row) {
+		c.AddMarketGroup(MarketGroup{
+			ID:       r.Int("marketGroupID"),
+			ParentID: r.Int("parentGroupID"),
+			Name:     r.String("marketGroupName"),
+		})
+	})
+}
+
+// Packaged volumes, read after the types they apply to.
+func (c *Catalogue) readVolumes(in io.Reader) error {
+	return sdecsv.Read(in, volumeColumns, func(r *sdecsv.Row) {
+		if t, ok := c.Types[r.Int("typeID")]; ok {
+			t.PackagedVolume = r.Float("volume")
+		}
+	})
+}
+
+func (c *Catalogue) writeTypes(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(c.Types))
+	for id := range c.Types {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		t := c.Types[id]
+		records = append(records, []string{sdecsv.FormatInt(t.ID), sdecsv.FormatInt(t.GroupID), t.Name,
+			sdecsv.FormatFloat(t.Volume), sdecsv.FormatInt(t.PortionSize), sdecsv.FormatFloat(t.BasePrice),
+			sdecsv.FormatBool(t.Published), sdecsv.FormatInt(t.MarketGroupID)})
+	}
+	return sdecsv.Write(out, typeColumns, records)
+}
+
+func (c *Catalogue) writeGroups(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(c.Groups))
+	for id := range c.Groups {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		g := c.Groups[id]
+		records = append(records, []string{sdecsv.FormatInt(g.ID), sdecsv.FormatInt(g.CategoryID), g.Name,
+			sdecsv.FormatBool(g.Published)})
+	}
+	return sdecsv.Write(out, groupColumns, records)
+}
+
+func (c *Catalogue) writeCategories(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(c.Categories))
+	for id := range c.Categories {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		cat := c.Categories[id]
+		records = append(records, []string{sdecsv.FormatInt(cat.ID), cat.Name, sdecsv.FormatBool(cat.Published)})
+	}
+	return sdecsv.Write(out, categoryColumns, records)
+}
+
+func (c *Catalogue) writeMarketGroups(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(c.MarketGroups))
+	for id := range c.MarketGroups {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		m := c.MarketGroups[id]
+		records = append(records, []string{sdecsv.FormatInt(m.ID), sdecsv.FormatInt(m.ParentID), m.Name})
+	}
+	return sdecsv.Write(out, marketGroupColumns, records)
+}
+
+func (c *Catalogue) writeVolumes(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(c.Types))
+	for id, t := range c.Types {
+		if t.PackagedVolume > 0 {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		records = append(records, []string{sdecsv.FormatInt(id), sdecsv.FormatFloat(c.Types[id].PackagedVolume)})
+	}
+	return sdecsv.Write(out, volumeColumns, records)
+}
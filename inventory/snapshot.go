@@ -0,0 +1,132 @@
+package inventory
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/antihax/eveapi"
+	"github.com/antihax/eveapi/internal/href"
+	"github.com/antihax/eveapi/internal/workers"
+)
+
+// Snapshot builds the catalogue from the CREST inventory and market group
+// endpoints, fetching the details of every category, group and type with
+// concurrency workers. CREST has no base prices or packaged volumes, so those are
+// left empty. Save the result to avoid repeating the snapshot.
+func Snapshot(c *eveapi.EVEAPIClient, concurrency int) (*Catalogue, error) {
+	cat := New()
+	mu := sync.Mutex{}
+
+	// Type names.
+	types, err := c.ItemTypesV1ByPage(1)
+	for ; types != nil && err == nil; types, err = types.NextPage() {
+		for _, t := range types.Items {
+			cat.AddType(Type{ID: t.ID, Name: t.Name})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := c.ItemCategoriesV1()
+	if err != nil {
+		return nil, err
+	}
+	err = workers.Run(concurrency, len(categories.Items), func(i int) error {
+		item := categories.Items[i]
+		category, err := c.ItemCategoryV1(item.Href)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		cat.AddCategory(Category{ID: item.ID, Name: category.Name, Published: category.Published})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups list the types in them.
+	var groupHrefs []string
+	var groupIDs []int64
+	groups, err := c.ItemGroupsV1ByPage(1)
+	for ; groups != nil && err == nil; groups, err = groups.NextPage() {
+		for _, g := range groups.Items {
+			groupHrefs = append(groupHrefs, g.Href)
+			groupIDs = append(groupIDs, g.ID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = workers.Run(concurrency, len(groupHrefs), func(i int) error {
+		group, err := c.ItemGroupV1(groupHrefs[i])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		cat.AddGroup(Group{
+			ID:         groupIDs[i],
+			CategoryID: href.ID(group.Category.Href),
+			Name:       group.Name,
+			Published:  group.Published,
+		})
+		for _, t := range group.Types {
+			cat.typ(t.ID).GroupID = groupIDs[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	marketGroups, err := c.MarketGroupsV1ByPage(1)
+	for ; marketGroups != nil && err == nil; marketGroups, err = marketGroups.NextPage() {
+		for _, m := range marketGroups.Items {
+			cat.AddMarketGroup(MarketGroup{ID: m.ID, ParentID: href.ID(m.ParentGroup.Href), Name: m.Name})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	marketTypes, err := c.MarketTypesV1ByPage(1)
+	for ; marketTypes != nil && err == nil; marketTypes, err = marketTypes.NextPage() {
+		for _, m := range marketTypes.Items {
+			cat.typ(m.Type.ID).MarketGroupID = m.MarketGroup.ID
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Type details for volumes.
+	ids := make([]int64, 0, len(cat.Types))
+	for id := range cat.Types {
+		ids = append(ids, id)
+	}
+	err = workers.Run(concurrency, len(ids), func(i int) error {
+		t, err := c.ItemTypeV3ByID(ids[i])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		typ := cat.Types[ids[i]]
+		typ.Volume = t.Volume
+		typ.PortionSize = t.PortionSize
+		typ.Published = t.Published
+		if typ.Name == "" {
+			typ.Name = t.Name
+			cat.typeNames[strings.ToLower(t.Name)] = ids[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cat, nil
+}
@@ -0,0 +1,191 @@
+package eveapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BountyDetails are the NPC kills paid by a bounty entry.
+type BountyDetails struct {
+	SolarSystemID   int64
+	SolarSystemName string
+	Kills           []BountyKill
+	// Truncated is set when the server cut the kill list short.
+	Truncated bool
+}
+
+// BountyKill is the number of NPCs of a type killed.
+type BountyKill struct {
+	TypeID int64
+	Count  int64
+}
+
+// TransferDetails is ISK moved between two parties, such as a player donation.
+type TransferDetails struct {
+	FromID   int64
+	FromName string
+	ToID     int64
+	ToName   string
+	Reason   string
+}
+
+// TaxDetails is corporation tax taken from the income of a member.
+type TaxDetails struct {
+	PayerID      int64
+	PayerName    string
+	ReceiverID   int64
+	ReceiverName string
+}
+
+// TransactionDetails points an entry at its market transaction.
+type TransactionDetails struct {
+	TransactionID int64
+}
+
+// AgentDetails is the agent paying a mission reward.
+type AgentDetails struct {
+	AgentID   int64
+	AgentName string
+}
+
+// WithdrawalDetails is the character withdrawing from a corporation account.
+type WithdrawalDetails struct {
+	CharacterID   int64
+	CharacterName string
+}
+
+// JournalDecoder interprets the arguments of journal entries of one ref type.
+type JournalDecoder func(e *WalletJournalEntryXML) (interface{}, error)
+
+var (
+	journalDecodersMu sync.RWMutex
+	journalDecoders   = map[RefType]JournalDecoder{
+		RefTypePlayerDonation:                            decodeTransfer,
+		RefTypeCorporationPayment:                        decodeTransfer,
+		RefTypePlayerTrading:                             decodeTransfer,
+		RefTypeMarketTransaction:                         decodeTransaction,
+		RefTypeMarketEscrow:                              decodeTransaction,
+		RefTypeBountyPrize:                               decodeBountyPrize,
+		RefTypeBountyPrizes:                              decodeBountyPrizes,
+		RefTypeAgentMissionReward:                        decodeAgent,
+		RefTypeAgentMissionTimeBonusReward:               decodeAgent,
+		RefTypeCorporationAccountWithdrawal:              decodeWithdrawal,
+		RefTypeBountyPrizeCorporationTax:                 decodeTax,
+		RefTypeAgentMissionRewardCorporationTax:          decodeTax,
+		RefTypeAgentMissionTimeBonusRewardCorporationTax: decodeTax,
+	}
+)
+
+// RegisterJournalDecoder sets the decoder for a ref type, replacing any existing one.
+func RegisterJournalDecoder(r RefType, d JournalDecoder) {
+	journalDecodersMu.Lock()
+	defer journalDecodersMu.Unlock()
+	journalDecoders[r] = d
+}
+
+// RefType returns the typed ref type of the entry.
+func (e *WalletJournalEntryXML) RefType() RefType {
+	return RefType(e.RefTypeID)
+}
+
+// Details decodes the arguments of the entry into one of the *Details types, or
+// whatever a registered decoder returns. It returns nil for ref types without a decoder.
+func (e *WalletJournalEntryXML) Details() (interface{}, error) {
+	journalDecodersMu.RLock()
+	d, ok := journalDecoders[e.RefType()]
+	journalDecodersMu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return d(e)
+}
+
+// Counterparty returns the other side of the transfer from ownerID.
+func (d *TransferDetails) Counterparty(ownerID int64) (int64, string) {
+	if d.FromID == ownerID {
+		return d.ToID, d.ToName
+	}
+	return d.FromID, d.FromName
+}
+
+// Count returns the number of NPCs killed.
+func (b *BountyDetails) Count() int64 {
+	var n int64
+	for _, k := range b.Kills {
+		n += k.Count
+	}
+	return n
+}
+
+func decodeTransfer(e *WalletJournalEntryXML) (interface{}, error) {
+	return &TransferDetails{
+		FromID:   e.OwnerID1,
+		FromName: e.OwnerName1,
+		ToID:     e.OwnerID2,
+		ToName:   e.OwnerName2,
+		Reason:   strings.TrimPrefix(e.Reason, "DESC: "),
+	}, nil
+}
+
+// The transaction ID is in argName1.
+func decodeTransaction(e *WalletJournalEntryXML) (interface{}, error) {
+	id, err := strconv.ParseInt(e.ArgName1, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ref %d: bad transaction ID %q", e.RefID, e.ArgName1)
+	}
+	return &TransactionDetails{id}, nil
+}
+
+// A single bounty names the NPC type in argID1.
+func decodeBountyPrize(e *WalletJournalEntryXML) (interface{}, error) {
+	return &BountyDetails{Kills: []BountyKill{{e.ArgID1, 1}}}, nil
+}
+
+// Bounty prizes name the solar system in the arguments and list the kills in the
+// reason as typeID:count pairs separated by commas.
+func decodeBountyPrizes(e *WalletJournalEntryXML) (interface{}, error) {
+	b := &BountyDetails{SolarSystemID: e.ArgID1, SolarSystemName: e.ArgName1}
+	for _, pair := range strings.Split(e.Reason, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		if pair == "..." {
+			b.Truncated = true
+			continue
+		}
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("ref %d: bad bounty kill %q", e.RefID, pair)
+		}
+		typeID, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ref %d: bad bounty kill %q", e.RefID, pair)
+		}
+		count, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("ref %d: bad bounty kill %q", e.RefID, pair)
+		}
+		b.Kills = append(b.Kills, BountyKill{typeID, count})
+	}
+	return b, nil
+}
+
+func decodeAgent(e *WalletJournalEntryXML) (interface{}, error) {
+	return &AgentDetails{AgentID: e.ArgID1, AgentName: e.ArgName1}, nil
+}
+
+func decodeWithdrawal(e *WalletJournalEntryXML) (interface{}, error) {
+	return &WithdrawalDetails{CharacterID: e.ArgID1, CharacterName: e.ArgName1}, nil
+}
+
+func decodeTax(e *WalletJournalEntryXML) (interface{}, error) {
+	return &TaxDetails{
+		PayerID:      e.OwnerID1,
+		PayerName:    e.OwnerName1,
+		ReceiverID:   e.OwnerID2,
+		ReceiverName: e.OwnerName2,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package eveapi
+
+import "testing"
+
+func TestJournalDetails(t *testing.T) {
+	bounty := &WalletJournalEntryXML{
+		RefID:     1,
+		RefTypeID: 85,
+		ArgName1:  "Jita",
+		ArgID1:    30000142,
+		Reason:    "23:2,24:1,...",
+	}
+	d, err := bounty.Details()
+	if err != nil {
+		t.Fatalf("Error decoding bounty %v", err)
+	}
+	b, ok := d.(*BountyDetails)
+	if !ok || b.SolarSystemID != 30000142 || len(b.Kills) != 2 || b.Count() != 3 || !b.Truncated {
+		t.Errorf("Wrong bounty details %+v", d)
+	}
+	if bounty.RefType().String() != "Bounty Prizes" {
+		t.Errorf("Wrong ref type name %s", bounty.RefType())
+	}
+
+	donation := &WalletJournalEntryXML{
+		RefTypeID:  10,
+		OwnerID1:   1,
+		OwnerName1: "Alpha",
+		OwnerID2:   2,
+		OwnerName2: "Beta",
+		Reason:     "DESC: thanks",
+	}
+	d, _ = donation.Details()
+	if tr, ok := d.(*TransferDetails); !ok || tr.Reason != "thanks" {
+		t.Errorf("Wrong donation details %+v", d)
+	} else if id, name := tr.Counterparty(2); id != 1 || name != "Alpha" {
+		t.Errorf("Wrong counterparty %d %s", id, name)
+	}
+
+	// Undecoded ref types have no details, and unknown ones still have a name.
+	if d, err := (&WalletJournalEntryXML{RefTypeID: 54}).Details(); d != nil || err != nil {
+		t.Errorf("Expected no details, got %v %v", d, err)
+	}
+	if s := RefType(9999).String(); s != "RefType(9999)" {
+		t.Errorf("Wrong unknown ref type name %s", s)
+	}
+
+	// The live table overrides bundled names.
+	x := &RefTypeXML{}
+	x.RefTypes = append(x.RefTypes, struct {
+		RefTypeName string `xml:"refTypeName,attr"`
+		RefTypeID   int64  `xml:"refTypeID,attr"`
+	}{"New Fee", 9999})
+	if table := x.Table(); table.Name(9999) != "New Fee" || table.Name(RefTypeBrokersFee) != "Brokers Fee" {
+		t.Errorf("Wrong ref type table")
+	}
+}
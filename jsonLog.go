@@ -0,0 +1,81 @@
+package eveapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// jsonLog is a file of JSON records appended one per line, so saving a change
+// does not rewrite the file. Stores replay the records when opened and compact
+// the file to their current state.
+type jsonLog struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+// openJSONLog replays the records of the file at path, if it exists, then
+// replaces the file with the records written by compact.
+func openJSONLog(path string, replay func(dec *json.Decoder) error, compact func(enc *json.Encoder) error) (*jsonLog, error) {
+	f, err := os.Open(path)
+	if err == nil {
+		dec := json.NewDecoder(bufio.NewReader(f))
+		for err == nil {
+			err = replay(dec)
+		}
+		f.Close()
+		if err != io.EOF {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// Write to a temporary file and swap it in so a crash does not lose the records.
+	tmp, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(tmp)
+	if err := compact(json.NewEncoder(w)); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return nil, err
+	}
+
+	f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonLog{path: path, f: f}, nil
+}
+
+// append writes a record to the end of the file.
+func (l *jsonLog) append(v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.f.Write(append(buf, '\n'))
+	return err
+}
+
+func (l *jsonLog) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
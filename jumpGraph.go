@@ -0,0 +1,162 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// JumpGraph is the stargate graph of solar systems, with the stations in them.
+type JumpGraph struct {
+	systems  map[int64]*jumpGraphSystem
+	stations map[int64]int64
+}
+
+type jumpGraphSystem struct {
+	ID       int64   `json:"id"`
+	RegionID int64   `json:"regionID"`
+	Security float64 `json:"security"`
+	Gates    []int64 `json:"gates"`
+}
+
+func NewJumpGraph() *JumpGraph {
+	return &JumpGraph{
+		systems:  make(map[int64]*jumpGraphSystem),
+		stations: make(map[int64]int64),
+	}
+}
+
+func (g *JumpGraph) system(id int64) *jumpGraphSystem {
+	s := g.systems[id]
+	if s == nil {
+		s = &jumpGraphSystem{ID: id}
+		g.systems[id] = s
+	}
+	return s
+}
+
+// AddSolarSystem adds a solar system or updates its region and security status.
+func (g *JumpGraph) AddSolarSystem(solarSystemID int64, regionID int64, security float64) {
+	s := g.system(solarSystemID)
+	s.RegionID = regionID
+	s.Security = security
+}
+
+// AddJump adds a stargate connection in both directions.
+func (g *JumpGraph) AddJump(fromSolarSystemID int64, toSolarSystemID int64) {
+	g.addGate(fromSolarSystemID, toSolarSystemID)
+	g.addGate(toSolarSystemID, fromSolarSystemID)
+}
+
+func (g *JumpGraph) addGate(from, to int64) {
+	s := g.system(from)
+	for _, id := range s.Gates {
+		if id == to {
+			return
+		}
+	}
+	s.Gates = append(s.Gates, to)
+}
+
+// AddStation places a station in a solar system.
+func (g *JumpGraph) AddStation(stationID int64, solarSystemID int64) {
+	g.stations[stationID] = solarSystemID
+}
+
+// StationSolarSystemID returns the solar system of a station.
+func (g *JumpGraph) StationSolarSystemID(stationID int64) (int64, bool) {
+	id, ok := g.stations[stationID]
+	return id, ok
+}
+
+// SolarSystemRegionID returns the region of a solar system.
+func (g *JumpGraph) SolarSystemRegionID(solarSystemID int64) (int64, bool) {
+	s, ok := g.systems[solarSystemID]
+	if !ok {
+		return 0, false
+	}
+	return s.RegionID, true
+}
+
+// SolarSystemSecurity returns the security status of a solar system.
+func (g *JumpGraph) SolarSystemSecurity(solarSystemID int64) (float64, bool) {
+	s, ok := g.systems[solarSystemID]
+	if !ok {
+		return 0, false
+	}
+	return s.Security, true
+}
+
+// Neighbours returns the solar systems one jump from a solar system.
+func (g *JumpGraph) Neighbours(solarSystemID int64) []int64 {
+	s, ok := g.systems[solarSystemID]
+	if !ok {
+		return nil
+	}
+	return s.Gates
+}
+
+// Jumps returns the number of jumps on the shortest path between two solar systems.
+func (g *JumpGraph) Jumps(from int64, to int64) (int, bool) {
+	return g.jumpsWithin(from, to, -1)
+}
+
+// Breadth first search up to max jumps, or without a limit if max is negative.
+func (g *JumpGraph) jumpsWithin(from int64, to int64, max int) (int, bool) {
+	if _, ok := g.systems[from]; !ok {
+		return 0, false
+	}
+	if from == to {
+		return 0, true
+	}
+
+	visited := map[int64]bool{from: true}
+	frontier := []int64{from}
+	for jumps := 1; len(frontier) > 0 && (max < 0 || jumps <= max); jumps++ {
+		var next []int64
+		for _, id := range frontier {
+			for _, n := range g.systems[id].Gates {
+				if n == to {
+					return jumps, true
+				}
+				if !visited[n] && g.systems[n] != nil {
+					visited[n] = true
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+	return 0, false
+}
+
+type jumpGraphJSON struct {
+	SolarSystems []*jumpGraphSystem `json:"solarSystems"`
+	Stations     map[int64]int64    `json:"stations"`
+}
+
+// LoadJumpGraph reads a jump graph written by Write.
+func LoadJumpGraph(r io.Reader) (*JumpGraph, error) {
+	j := jumpGraphJSON{}
+	if err := json.NewDecoder(r).Decode(&j); err != nil {
+		return nil, err
+	}
+	g := NewJumpGraph()
+	for _, s := range j.SolarSystems {
+		g.systems[s.ID] = s
+	}
+	for station, system := range j.Stations {
+		g.stations[station] = system
+	}
+	return g, nil
+}
+
+// Write saves the jump graph as JSON.
+func (g *JumpGraph) Write(w io.Writer) error {
+	j := jumpGraphJSON{Stations: g.stations}
+	for _, s := range g.systems {
+		j.SolarSystems = append(j.SolarSystems, s)
+	}
+	sort.Slice(j.SolarSystems, func(a, b int) bool { return j.SolarSystems[a].ID < j.SolarSystems[b].ID })
+	return json.NewEncoder(w).Encode(j)
+}
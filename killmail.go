@@ -2,14 +2,116 @@ package eveapi
 
 import (
 	"crypto/sha1"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"time"
 )
 
-// Moved to ESI
-// See https://github.com/antihax/swagger-esi-goclient
+const killmailV1Type = "application/vnd.ccp.eve.Killmail-v1"
+
+type KillmailV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+
+	KillID        int64
+	KillTime      EVEKillmailTime
+	SolarSystem   namedReference
+	War           idHref
+	AttackerCount int64
+	Attackers     []KillmailAttackerV1
+	Victim        KillmailVictimV1
+}
+
+type KillmailAttackerV1 struct {
+	Character      namedReference
+	Corporation    namedReference
+	Alliance       namedReference
+	ShipType       namedReference
+	WeaponType     namedReference
+	DamageDone     int64
+	FinalBlow      bool
+	SecurityStatus float64
+}
+
+type KillmailVictimV1 struct {
+	Character   namedReference
+	Corporation namedReference
+	Alliance    namedReference
+	ShipType    namedReference
+	DamageTaken int64
+	Items       []KillmailItemV1
+	Position    struct {
+		X float64
+		Y float64
+		Z float64
+	}
+}
+
+// KillmailItemV1 is an item fitted to or carried by the victim.
+// Containers hold their contents in Items.
+type KillmailItemV1 struct {
+	ItemType          namedReference
+	Flag              int64
+	Singleton         int64
+	QuantityDestroyed int64
+	QuantityDropped   int64
+	Items             []KillmailItemV1
+}
+
+// ErrKillmailHashMismatch is returned when a killmail does not match its hash.
+var ErrKillmailHashMismatch = errors.New("killmail does not match hash")
+
+func (c *EVEAPIClient) KillmailV1(href string) (*KillmailV1, error) {
+	w := &KillmailV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, killmailV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+// KillmailV1ByIDHash fetches a public killmail and verifies it against its hash.
+func (c *EVEAPIClient) KillmailV1ByIDHash(id int64, hash string) (*KillmailV1, error) {
+	href := c.base.CREST + fmt.Sprintf("killmails/%d/%s/", id, hash)
+	k, err := c.KillmailV1(href)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Verify(hash); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// FinalBlow returns the attacker who landed the final blow, or nil if there is none.
+func (c *KillmailV1) FinalBlow() *KillmailAttackerV1 {
+	for i := range c.Attackers {
+		if c.Attackers[i].FinalBlow {
+			return &c.Attackers[i]
+		}
+	}
+	return nil
+}
+
+// Hash recomputes the killmail hash from the victim, final blow attacker, ship and time.
+func (c *KillmailV1) Hash() string {
+	var attackerID int64
+	if a := c.FinalBlow(); a != nil {
+		attackerID = a.Character.ID
+	}
+	return GenerateKillMailHash(c.Victim.Character.ID, attackerID, c.Victim.ShipType.ID, c.KillTime.Time)
+}
+
+// Verify returns ErrKillmailHashMismatch if the killmail does not match the hash.
+func (c *KillmailV1) Verify(hash string) error {
+	if c.Hash() != hash {
+		return ErrKillmailHashMismatch
+	}
+	return nil
+}
 
 // Generate the killmail hash using source information.
 func GenerateKillMailHash(victimID int64, attackerID int64, shipTypeID int64, killTime time.Time) string {
@@ -1,6 +1,7 @@
 package eveapi
 
 import (
+	"encoding/json"
 	"strconv"
 	"testing"
 	"time"
@@ -35,3 +36,68 @@ func TestKillmailTime(t *testing.T) {
 		t.Errorf("Time does not match %d != %d %s %s", 130521094800000000, d, ti.String(), tm.String())
 	}
 }
+
+const testKillmail = `{
+	"solarSystem": {"href": "https://crest-tq.eveonline.com/solarsystems/30002187/", "id": 30002187, "name": "Amarr"},
+	"killID": 41240138,
+	"killTime": "2014.08.10 01:58:00",
+	"attackerCount": 2,
+	"attackers": [
+		{"character": {"id": 90000001, "name": "Helper"}, "shipType": {"id": 587, "name": "Rifter"}, "damageDone": 100, "finalBlow": false},
+		{"character": {"id": 93808108, "name": "Killer"}, "shipType": {"id": 587, "name": "Rifter"}, "damageDone": 900, "finalBlow": true}
+	],
+	"victim": {
+		"shipType": {"id": 24646, "name": "Ishukone Watch Small Shield Battery"},
+		"damageTaken": 1000,
+		"items": [
+			{"itemType": {"id": 3467, "name": "Small Secure Container"}, "flag": 5, "singleton": 1, "quantityDestroyed": 1,
+				"items": [{"itemType": {"id": 34, "name": "Tritanium"}, "flag": 0, "quantityDropped": 1000}]}
+		]
+	}
+}`
+
+func TestKillmailVerify(t *testing.T) {
+	k := &KillmailV1{}
+	if err := json.Unmarshal([]byte(testKillmail), k); err != nil {
+		t.Fatalf("Error decoding killmail %v", err)
+	}
+
+	if a := k.FinalBlow(); a == nil || a.Character.ID != 93808108 {
+		t.Errorf("Wrong final blow attacker")
+	}
+	if len(k.Victim.Items) != 1 || len(k.Victim.Items[0].Items) != 1 {
+		t.Errorf("Nested items not decoded")
+	}
+	if err := k.Verify("efd4bf9c4f2aee704d3f9a7f8ae0176a15eba19d"); err != nil {
+		t.Errorf("Killmail failed verification %v", err)
+	}
+
+	// Tamper with the final blow
+	k.Attackers[0].FinalBlow, k.Attackers[1].FinalBlow = true, false
+	if err := k.Verify("efd4bf9c4f2aee704d3f9a7f8ae0176a15eba19d"); err != ErrKillmailHashMismatch {
+		t.Errorf("Tampered killmail passed verification")
+	}
+}
+
+func TestKillmailRoundTrip(t *testing.T) {
+	k := &KillmailV1{}
+	if err := json.Unmarshal([]byte(testKillmail), k); err != nil {
+		t.Fatalf("Error decoding killmail %v", err)
+	}
+
+	// A stored killmail loads again with the same hash.
+	buf, err := json.Marshal(k)
+	if err != nil {
+		t.Fatalf("Error encoding killmail %v", err)
+	}
+	stored := &KillmailV1{}
+	if err := json.Unmarshal(buf, stored); err != nil {
+		t.Fatalf("Error decoding stored killmail %v", err)
+	}
+	if !stored.KillTime.Equal(k.KillTime.Time) {
+		t.Errorf("Kill time changed %v != %v", stored.KillTime, k.KillTime)
+	}
+	if err := stored.Verify("efd4bf9c4f2aee704d3f9a7f8ae0176a15eba19d"); err != nil {
+		t.Errorf("Stored killmail failed verification %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package eveapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const characterLocationV1Type = "application/vnd.ccp.eve.CharacterLocation-v1"
+
+type CharacterLocationV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+
+	SolarSystem namedReference
+	Station     namedReference
+}
+
+// Online returns true if the character is logged in. Offline characters have no location.
+func (c *CharacterLocationV1) Online() bool {
+	return c.SolarSystem.ID != 0
+}
+
+func (c *EVEAPIClient) CharacterLocationV1(auth oauth2.TokenSource, href string) (*CharacterLocationV1, error) {
+	w := &CharacterLocationV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, characterLocationV1Type, auth)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+// CharacterLocationV1ByID returns the live location of a character. Requires ScopeCharacterLocationRead.
+func (c *EVEAPIClient) CharacterLocationV1ByID(auth oauth2.TokenSource, characterID int64) (*CharacterLocationV1, error) {
+	href := c.base.CREST + fmt.Sprintf("characters/%d/location/", characterID)
+	return c.CharacterLocationV1(auth, href)
+}
+
+// LocationV1 returns the live location of the character. Requires ScopeCharacterLocationRead.
+func (c *CharacterV4) LocationV1(auth oauth2.TokenSource) (*CharacterLocationV1, error) {
+	return c.CharacterLocationV1(auth, c.href(c.Location, "location/"))
+}
+
+// LocationRecord is a location of a character from the time it was first seen.
+// A SolarSystemID of zero means the character was offline.
+type LocationRecord struct {
+	CharacterID     int64
+	SolarSystemID   int64
+	SolarSystemName string
+	StationID       int64
+	StationName     string
+	Time            time.Time
+}
+
+// LocationChange is sent when a tracked character changes solar system.
+// The first location seen for a character is sent with an empty Previous.
+type LocationChange struct {
+	CharacterID int64
+	Previous    LocationRecord
+	Current     LocationRecord
+}
+
+// LocationTracker polls the location of authenticated characters at their cache
+// interval and records their location history.
+type LocationTracker struct {
+	client *EVEAPIClient
+	events chan LocationChange
+
+	// MinInterval is the minimum time between polls of one character.
+	MinInterval time.Duration
+	// HistoryLimit is the number of records kept per character.
+	HistoryLimit int
+	// OnError is called when polling a character fails, if set.
+	OnError func(characterID int64, err error)
+
+	mu         sync.Mutex
+	wg         sync.WaitGroup
+	characters map[int64]*trackedCharacter
+	stopped    bool
+}
+
+type trackedCharacter struct {
+	auth    oauth2.TokenSource
+	stop    chan struct{}
+	history []LocationRecord
+}
+
+// NewLocationTracker creates a location tracker. Call Track to add characters and
+// read changes from Events.
+func NewLocationTracker(c *EVEAPIClient) *LocationTracker {
+	return &LocationTracker{
+		client:       c,
+		events:       make(chan LocationChange, 100),
+		MinInterval:  5 * time.Second,
+		HistoryLimit: 1000,
+		characters:   make(map[int64]*trackedCharacter),
+	}
+}
+
+// Events returns the channel of solar system changes. The channel is closed by Stop.
+func (t *LocationTracker) Events() <-chan LocationChange {
+	return t.events
+}
+
+// Track starts polling the character using the token source.
+// Tracking an already tracked character replaces its token source.
+func (t *LocationTracker) Track(characterID int64, auth oauth2.TokenSource) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
+	if ch, ok := t.characters[characterID]; ok {
+		ch.auth = auth
+		return
+	}
+
+	ch := &trackedCharacter{auth: auth, stop: make(chan struct{})}
+	t.characters[characterID] = ch
+	t.wg.Add(1)
+	go t.poll(characterID, ch)
+}
+
+// Untrack stops polling the character and drops its history.
+func (t *LocationTracker) Untrack(characterID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ch, ok := t.characters[characterID]; ok {
+		close(ch.stop)
+		delete(t.characters, characterID)
+	}
+}
+
+// Stop stops polling all characters and closes the events channel.
+func (t *LocationTracker) Stop() {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	t.stopped = true
+	for id, ch := range t.characters {
+		close(ch.stop)
+		delete(t.characters, id)
+	}
+	t.mu.Unlock()
+
+	t.wg.Wait()
+	close(t.events)
+}
+
+// History returns the recorded locations of the character, oldest first.
+func (t *LocationTracker) History(characterID int64) []LocationRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch, ok := t.characters[characterID]
+	if !ok {
+		return nil
+	}
+	h := make([]LocationRecord, len(ch.history))
+	copy(h, ch.history)
+	return h
+}
+
+// Current returns the last known location of the character.
+func (t *LocationTracker) Current(characterID int64) (LocationRecord, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch, ok := t.characters[characterID]
+	if !ok || len(ch.history) == 0 {
+		return LocationRecord{}, false
+	}
+	return ch.history[len(ch.history)-1], true
+}
+
+// Poll a character until stopped.
+func (t *LocationTracker) poll(characterID int64, ch *trackedCharacter) {
+	defer t.wg.Done()
+
+	for {
+		t.mu.Lock()
+		auth := ch.auth
+		t.mu.Unlock()
+
+		next := time.Now().Add(t.MinInterval)
+		l, err := t.client.CharacterLocationV1ByID(auth, characterID)
+		if err != nil {
+			if t.OnError != nil {
+				t.OnError(characterID, err)
+			}
+		} else {
+			if l.CacheUntil.After(next) {
+				next = l.CacheUntil
+			}
+			if change, ok := t.record(characterID, ch, l); ok {
+				select {
+				case t.events <- change:
+				case <-ch.stop:
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ch.stop:
+			return
+		case <-time.After(next.Sub(time.Now())):
+		}
+	}
+}
+
+// Record a location if it changed. Returns a change if the solar system changed.
+func (t *LocationTracker) record(characterID int64, ch *trackedCharacter, l *CharacterLocationV1) (LocationChange, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r := LocationRecord{
+		CharacterID:     characterID,
+		SolarSystemID:   l.SolarSystem.ID,
+		SolarSystemName: l.SolarSystem.Name,
+		StationID:       l.Station.ID,
+		StationName:     l.Station.Name,
+		Time:            time.Now().UTC(),
+	}
+
+	var last LocationRecord
+	first := len(ch.history) == 0
+	if !first {
+		last = ch.history[len(ch.history)-1]
+		if last.SolarSystemID == r.SolarSystemID && last.StationID == r.StationID {
+			return LocationChange{}, false
+		}
+	}
+
+	ch.history = append(ch.history, r)
+	if t.HistoryLimit > 0 && len(ch.history) > t.HistoryLimit {
+		ch.history = ch.history[len(ch.history)-t.HistoryLimit:]
+	}
+
+	if !first && last.SolarSystemID == r.SolarSystemID {
+		return LocationChange{}, false
+	}
+	return LocationChange{CharacterID: characterID, Previous: last, Current: r}, true
+}
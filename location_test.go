@@ -0,0 +1,115 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestCharacterLocation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/characters/90000001/location/" || r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Wrong request %s %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `{"solarSystem": {"id": 30000142, "name": "Jita"}, "station": {"id": 60003760, "name": "Jita IV - Moon 4"}}`)
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	auth := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+
+	l, err := c.CharacterLocationV1ByID(auth, 90000001)
+	if err != nil {
+		t.Fatalf("Error getting location %v", err)
+	}
+	if !l.Online() || l.SolarSystem.ID != 30000142 || l.Station.Name != "Jita IV - Moon 4" {
+		t.Errorf("Wrong location %+v", l)
+	}
+	if (&CharacterLocationV1{}).Online() {
+		t.Errorf("Empty location is online")
+	}
+}
+
+func TestLocationTracker(t *testing.T) {
+	// Each poll returns the next location, staying offline at the end.
+	locations := []string{
+		`{"solarSystem": {"id": 30000142, "name": "Jita"}}`,
+		`{"solarSystem": {"id": 30000142, "name": "Jita"}}`,
+		`{"solarSystem": {"id": 30000142, "name": "Jita"}, "station": {"id": 60003760, "name": "Jita IV - Moon 4"}}`,
+		`{"solarSystem": {"id": 30002187, "name": "Amarr"}}`,
+		`{}`,
+	}
+	mu := sync.Mutex{}
+	polls := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		i := polls[r.URL.Path]
+		polls[r.URL.Path]++
+		mu.Unlock()
+		if i >= len(locations) {
+			i = len(locations) - 1
+		}
+		fmt.Fprint(w, locations[i])
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	auth := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+
+	tracker := NewLocationTracker(c)
+	tracker.MinInterval = 5 * time.Millisecond
+	tracker.OnError = func(characterID int64, err error) {
+		t.Errorf("Error polling %d: %v", characterID, err)
+	}
+	tracker.Track(1, auth)
+
+	next := func() LocationChange {
+		select {
+		case change := <-tracker.Events():
+			return change
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for a location change")
+		}
+		return LocationChange{}
+	}
+
+	// Docking in the same system is recorded but is not a change.
+	if change := next(); change.CharacterID != 1 || change.Previous.SolarSystemID != 0 || change.Current.SolarSystemID != 30000142 {
+		t.Errorf("Wrong first change %+v", change)
+	}
+	if change := next(); change.Previous.StationID != 60003760 || change.Current.SolarSystemID != 30002187 {
+		t.Errorf("Wrong jump %+v", change)
+	}
+	if change := next(); change.Previous.SolarSystemID != 30002187 || change.Current.SolarSystemID != 0 {
+		t.Errorf("Wrong logoff %+v", change)
+	}
+
+	history := tracker.History(1)
+	if len(history) != 4 || history[1].StationID != 60003760 {
+		t.Errorf("Wrong history %+v", history)
+	}
+	if current, ok := tracker.Current(1); !ok || current.SolarSystemID != 0 {
+		t.Errorf("Wrong current location %+v", current)
+	}
+
+	tracker.Untrack(1)
+	if tracker.History(1) != nil {
+		t.Errorf("History kept after untracking")
+	}
+	if _, ok := tracker.Current(1); ok {
+		t.Errorf("Current location kept after untracking")
+	}
+
+	tracker.Track(2, auth)
+	tracker.Stop()
+	tracker.Stop()
+	for range tracker.Events() {
+	}
+	tracker.Track(3, auth)
+	if _, ok := tracker.Current(3); ok {
+		t.Errorf("Tracked a character after stopping")
+	}
+}
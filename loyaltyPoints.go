@@ -0,0 +1,162 @@
+package eveapi
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/oauth2"
+)
+
+const loyaltyPointsCollectionV1Type = "application/vnd.ccp.eve.LoyaltyPointsCollection-v1"
+
+type LoyaltyPointsCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+
+	Items []struct {
+		PointsAccrued int64
+		Corporation   namedReference
+	}
+}
+
+func (c *EVEAPIClient) LoyaltyPointsV1(auth oauth2.TokenSource, href string) (*LoyaltyPointsCollectionV1, error) {
+	w := &LoyaltyPointsCollectionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, loyaltyPointsCollectionV1Type, auth)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(href, res)
+	return w, nil
+}
+
+// LoyaltyPointsV1ByID returns the loyalty points of a character with each NPC corporation.
+// Requires ScopeCharacterLoyaltyPointsRead.
+func (c *EVEAPIClient) LoyaltyPointsV1ByID(auth oauth2.TokenSource, characterID int64) (*LoyaltyPointsCollectionV1, error) {
+	href := c.base.CREST + fmt.Sprintf("characters/%d/loyaltypoints/", characterID)
+	return c.LoyaltyPointsV1(auth, href)
+}
+
+// LoyaltyPointsV1 returns the loyalty points of the character with each NPC corporation.
+// Requires ScopeCharacterLoyaltyPointsRead.
+func (c *CharacterV4) LoyaltyPointsV1(auth oauth2.TokenSource) (*LoyaltyPointsCollectionV1, error) {
+	return c.EVEAPIClient.LoyaltyPointsV1(auth, c.href(c.LoyaltyPoints, "loyaltypoints/"))
+}
+
+// Balance returns the loyalty points with an NPC corporation.
+func (c *LoyaltyPointsCollectionV1) Balance(corporationID int64) int64 {
+	for _, i := range c.Items {
+		if i.Corporation.ID == corporationID {
+			return i.PointsAccrued
+		}
+	}
+	return 0
+}
+
+// LPOfferValue is the value of a loyalty store offer at market prices.
+type LPOfferValue struct {
+	OfferID  int64
+	TypeID   int64
+	TypeName string
+	Quantity int64
+	LPCost   int64
+	ISKCost  int64
+
+	RequiredCost float64 // Cost of buying the required items
+	Revenue      float64 // Value of the output after taxes and fees
+	Profit       float64 // Revenue less the ISK and required item costs
+	ISKPerLP     float64 // Profit per loyalty point spent
+
+	Priced     bool // All items in the offer have a price
+	Affordable bool // The budget covers the LP, ISK and required items
+}
+
+// LPBudget is what a character can spend on loyalty store offers.
+type LPBudget struct {
+	LP  int64
+	ISK float64
+	// Items are the required items already held, by typeID. Required items not
+	// held must be bought at the lowest sell order.
+	Items map[int64]int64
+}
+
+// LPAnalyzer values loyalty store offers using market prices for a region.
+type LPAnalyzer struct {
+	Prices MarketPrices
+
+	// SalesTax and BrokerFee are fractions of the sale price, e.g. 0.02.
+	SalesTax  float64
+	BrokerFee float64
+
+	// SellToBuyOrders values the output at the highest buy order, which avoids the broker fee.
+	// Otherwise the output is valued at the lowest sell order.
+	SellToBuyOrders bool
+}
+
+// Analyze values every offer in the pages of a loyalty store and ranks them by ISK per LP.
+// Offers missing prices are ranked last. The budget marks which offers are affordable.
+func (a *LPAnalyzer) Analyze(budget LPBudget, stores ...*LoyaltyStoreOffersCollectionV1) []LPOfferValue {
+	var values []LPOfferValue
+
+	for _, store := range stores {
+		for _, o := range store.Items {
+			v := LPOfferValue{
+				OfferID:    o.ID,
+				TypeID:     o.Item.ID,
+				TypeName:   o.Item.Name,
+				Quantity:   o.Quantity,
+				LPCost:     o.LpCost,
+				ISKCost:    o.IskCost,
+				Priced:     true,
+				Affordable: budget.LP >= o.LpCost,
+			}
+
+			// Required items are valued at sell orders, and those not held must
+			// be bought to redeem the offer.
+			buyable := true
+			var toBuy float64
+			for _, r := range o.RequiredItems {
+				p := a.Prices[r.Item.ID].Sell
+				if p == 0 {
+					v.Priced = false
+				}
+				v.RequiredCost += p * float64(r.Quantity)
+
+				if missing := r.Quantity - budget.Items[r.Item.ID]; missing > 0 {
+					if p == 0 {
+						buyable = false
+					}
+					toBuy += p * float64(missing)
+				}
+			}
+			v.Affordable = v.Affordable && buyable && budget.ISK >= float64(o.IskCost)+toBuy
+
+			var price, fees float64
+			if a.SellToBuyOrders {
+				price = a.Prices[o.Item.ID].Buy
+				fees = a.SalesTax
+			} else {
+				price = a.Prices[o.Item.ID].Sell
+				fees = a.SalesTax + a.BrokerFee
+			}
+			if price == 0 {
+				v.Priced = false
+			}
+
+			v.Revenue = price * float64(o.Quantity) * (1 - fees)
+			v.Profit = v.Revenue - float64(o.IskCost) - v.RequiredCost
+			if o.LpCost > 0 {
+				v.ISKPerLP = v.Profit / float64(o.LpCost)
+			}
+			values = append(values, v)
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Priced != values[j].Priced {
+			return values[i].Priced
+		}
+		return values[i].ISKPerLP > values[j].ISKPerLP
+	})
+
+	return values
+}
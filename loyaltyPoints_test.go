@@ -0,0 +1,69 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+const testLPStore = `{"items": [
+	{"id": 1, "lpCost": 1000, "iskCost": 100000, "quantity": 10, "item": {"id": 100, "name": "Ammo"}, "requiredItems": []},
+	{"id": 2, "lpCost": 500, "iskCost": 0, "quantity": 1, "item": {"id": 200, "name": "Implant"},
+		"requiredItems": [{"item": {"id": 300, "name": "Tag"}, "quantity": 2}]},
+	{"id": 3, "lpCost": 10, "iskCost": 0, "quantity": 1, "item": {"id": 400, "name": "Unpriced"}, "requiredItems": []}
+]}`
+
+func TestLPAnalyzer(t *testing.T) {
+	store := &LoyaltyStoreOffersCollectionV1{}
+	if err := json.Unmarshal([]byte(testLPStore), store); err != nil {
+		t.Fatalf("Error decoding store %v", err)
+	}
+	a := &LPAnalyzer{
+		Prices: MarketPrices{
+			100: {Buy: 15000, Sell: 20000},
+			200: {Buy: 9000, Sell: 10000},
+			300: {Sell: 1000},
+		},
+		SalesTax:  0.02,
+		BrokerFee: 0.01,
+	}
+
+	values := a.Analyze(LPBudget{LP: 2000, ISK: 1000, Items: map[int64]int64{300: 2}}, store)
+	if len(values) != 3 {
+		t.Fatalf("Expected 3 offers, got %d", len(values))
+	}
+	if values[0].OfferID != 1 || values[1].OfferID != 2 || values[2].OfferID != 3 || values[2].Priced {
+		t.Errorf("Wrong ranking %+v", values)
+	}
+	if v := values[0]; math.Abs(v.Profit-94000) > 1e-6 || math.Abs(v.ISKPerLP-94) > 1e-6 {
+		t.Errorf("Wrong value of offer 1 %+v", v)
+	}
+	if v := values[1]; v.RequiredCost != 2000 || math.Abs(v.Profit-7700) > 1e-6 {
+		t.Errorf("Wrong value of offer 2 %+v", v)
+	}
+
+	// Offer 1 needs more ISK, offer 2 is covered by the held tags.
+	if values[0].Affordable || !values[1].Affordable {
+		t.Errorf("Wrong affordability with held items %v %v", values[0].Affordable, values[1].Affordable)
+	}
+
+	// Without the tags they must be bought, and unpriced items cannot be.
+	values = a.Analyze(LPBudget{LP: 2000, ISK: 1999}, store)
+	if values[1].Affordable {
+		t.Errorf("Offer 2 affordable without ISK for the tags")
+	}
+	values = a.Analyze(LPBudget{LP: 2000, ISK: 200000}, store)
+	if !values[0].Affordable || !values[1].Affordable || !values[2].Affordable {
+		t.Errorf("Offers not affordable with enough LP and ISK")
+	}
+	values = a.Analyze(LPBudget{LP: 999, ISK: 200000}, store)
+	if values[0].Affordable {
+		t.Errorf("Offer 1 affordable without enough LP")
+	}
+
+	a.SellToBuyOrders = true
+	values = a.Analyze(LPBudget{}, store)
+	if v := values[0]; math.Abs(v.Revenue-147000) > 1e-6 {
+		t.Errorf("Wrong revenue selling to buy orders %+v", v)
+	}
+}
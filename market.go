@@ -12,23 +12,25 @@ type MarketOrderCollectionSlimV1 struct {
 	*EVEAPIClient
 	crestPagedFrame
 
-	Items []struct {
-		Buy           bool
-		Issued        EVETime
-		Price         float64
-		VolumeEntered int64
-		MinVolume     int64
-		Volume        int64
-		Range         string
-		Duration      int64
-		ID            int64
-		Type          int64
-		StationID     int64
-	}
+	Items []MarketOrderSlimV1
 
 	RegionID int64 // We wil back fill this for convienence.
 }
 
+type MarketOrderSlimV1 struct {
+	Buy           bool
+	Issued        EVETime
+	Price         float64
+	VolumeEntered int64
+	MinVolume     int64
+	Volume        int64
+	Range         string
+	Duration      int64
+	ID            int64
+	Type          int64
+	StationID     int64
+}
+
 func (c *EVEAPIClient) MarketOrdersSlimV1(url string) (*MarketOrderCollectionSlimV1, error) {
 	w := &MarketOrderCollectionSlimV1{EVEAPIClient: c}
 	res, err := c.doJSON("GET", url, nil, w, marketOrderCollectionSlimV1Type, nil)
@@ -80,19 +82,21 @@ type MarketTypeHistoryCollectionV1 struct {
 	*EVEAPIClient
 	crestPagedFrame
 
-	Items []struct {
-		OrderCount int64
-		LowPrice   float64
-		HighPrice  float64
-		AvgPrice   float64
-		Volume     int64
-		Date       string
-	}
+	Items []MarketHistoryDayV1
 
 	RegionID int64 // We wil back fill this for convienence.
 	TypeID   int64 // We wil back fill this for convienence.
 }
 
+type MarketHistoryDayV1 struct {
+	OrderCount int64
+	LowPrice   float64
+	HighPrice  float64
+	AvgPrice   float64
+	Volume     int64
+	Date       EVETime
+}
+
 func (c *EVEAPIClient) MarketTypeHistory(url string) (*MarketTypeHistoryCollectionV1, error) {
 	w := &MarketTypeHistoryCollectionV1{EVEAPIClient: c}
 
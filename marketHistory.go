@@ -0,0 +1,177 @@
+package eveapi
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MarketHistory is a series of daily market history, oldest first.
+// Windows are counted in entries of the series, not calendar days.
+type MarketHistory []MarketHistoryDayV1
+
+// MarketHistoryPoint is a value of an indicator on a day.
+type MarketHistoryPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+// DonchianChannel is the highest high and lowest low over a window.
+type DonchianChannel struct {
+	Date   time.Time
+	Upper  float64
+	Lower  float64
+	Middle float64
+}
+
+// History returns the items as a series sorted oldest first.
+func (c *MarketTypeHistoryCollectionV1) History() MarketHistory {
+	h := make(MarketHistory, len(c.Items))
+	copy(h, c.Items)
+	h.sort()
+	return h
+}
+
+func (h MarketHistory) sort() {
+	sort.SliceStable(h, func(i, j int) bool { return h[i].Date.Before(h[j].Date.Time) })
+}
+
+// MovingAverage returns the simple moving average of the average price over a window.
+// The first point is at the end of the first full window.
+func (h MarketHistory) MovingAverage(window int) []MarketHistoryPoint {
+	if window < 1 || len(h) < window {
+		return nil
+	}
+	var points []MarketHistoryPoint
+	var sum float64
+	for i, d := range h {
+		sum += d.AvgPrice
+		if i >= window {
+			sum -= h[i-window].AvgPrice
+		}
+		if i >= window-1 {
+			points = append(points, MarketHistoryPoint{d.Date.Time, sum / float64(window)})
+		}
+	}
+	return points
+}
+
+// Donchian returns the Donchian channel of the high and low prices over a window.
+// The first channel is at the end of the first full window.
+func (h MarketHistory) Donchian(window int) []DonchianChannel {
+	if window < 1 || len(h) < window {
+		return nil
+	}
+	var channels []DonchianChannel
+	for i := window - 1; i < len(h); i++ {
+		c := DonchianChannel{Date: h[i].Date.Time, Upper: h[i].HighPrice, Lower: h[i].LowPrice}
+		for _, d := range h[i-window+1 : i] {
+			c.Upper = math.Max(c.Upper, d.HighPrice)
+			c.Lower = math.Min(c.Lower, d.LowPrice)
+		}
+		c.Middle = (c.Upper + c.Lower) / 2
+		channels = append(channels, c)
+	}
+	return channels
+}
+
+// Volatility returns the sample standard deviation of the daily log returns of the
+// average price over the last window entries.
+func (h MarketHistory) Volatility(window int) float64 {
+	if window < 1 {
+		return 0
+	}
+	if len(h) > window+1 {
+		h = h[len(h)-window-1:]
+	}
+	var returns []float64
+	for i := 1; i < len(h); i++ {
+		if h[i-1].AvgPrice > 0 && h[i].AvgPrice > 0 {
+			returns = append(returns, math.Log(h[i].AvgPrice/h[i-1].AvgPrice))
+		}
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+// VolumeTrend returns the least squares slope of the volume over the last window
+// entries, in units per day.
+func (h MarketHistory) VolumeTrend(window int) float64 {
+	if window < 1 {
+		return 0
+	}
+	if len(h) > window {
+		h = h[len(h)-window:]
+	}
+	if len(h) < 2 {
+		return 0
+	}
+
+	start := h[0].Date.Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, d := range h {
+		x := d.Date.Sub(start).Hours() / 24
+		y := float64(d.Volume)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	n := float64(len(h))
+	div := n*sumXX - sumX*sumX
+	if div == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / div
+}
+
+// Gaps returns the days missing between the first and last entries.
+func (h MarketHistory) Gaps() []time.Time {
+	var gaps []time.Time
+	for i := 1; i < len(h); i++ {
+		for d := h[i-1].Date.AddDate(0, 0, 1); d.Before(h[i].Date.Time); d = d.AddDate(0, 0, 1) {
+			gaps = append(gaps, d)
+		}
+	}
+	return gaps
+}
+
+// Merge appends the days of newer that are after the last day of the series, and
+// returns the merged series with the number of days added.
+func (h MarketHistory) Merge(newer MarketHistory) (MarketHistory, int) {
+	merged := make(MarketHistory, len(h), len(h)+len(newer))
+	copy(merged, h)
+	merged.sort()
+
+	var last time.Time
+	if len(merged) > 0 {
+		last = merged[len(merged)-1].Date.Time
+	}
+
+	sorted := make(MarketHistory, len(newer))
+	copy(sorted, newer)
+	sorted.sort()
+
+	added := 0
+	for _, d := range sorted {
+		if d.Date.After(last) {
+			merged = append(merged, d)
+			last = d.Date.Time
+			added++
+		}
+	}
+	return merged, added
+}
@@ -0,0 +1,90 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMarketHistory = `{
+	"items": [
+		{"volume": 400, "orderCount": 10, "lowPrice": 9, "highPrice": 13, "avgPrice": 12, "date": "2016-01-04T00:00:00"},
+		{"volume": 100, "orderCount": 10, "lowPrice": 8, "highPrice": 11, "avgPrice": 10, "date": "2016-01-01T00:00:00"},
+		{"volume": 200, "orderCount": 10, "lowPrice": 9, "highPrice": 12, "avgPrice": 11, "date": "2016-01-02T00:00:00"},
+		{"volume": 500, "orderCount": 10, "lowPrice": 10, "highPrice": 14, "avgPrice": 13, "date": "2016-01-05T00:00:00"}
+	],
+	"totalCount": 4,
+	"pageCount": 1
+}`
+
+func TestMarketHistory(t *testing.T) {
+	c := &MarketTypeHistoryCollectionV1{}
+	if err := json.Unmarshal([]byte(testMarketHistory), c); err != nil {
+		t.Fatalf("Error decoding history %v", err)
+	}
+	h := c.History()
+	if !h[0].Date.Equal(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("History not sorted %v", h[0].Date)
+	}
+
+	ma := h.MovingAverage(2)
+	if len(ma) != 3 || ma[0].Value != 10.5 || ma[2].Value != 12.5 {
+		t.Errorf("Wrong moving average %v", ma)
+	}
+
+	dc := h.Donchian(3)
+	if len(dc) != 2 || dc[0].Upper != 13 || dc[0].Lower != 8 || dc[1].Upper != 14 || dc[1].Lower != 9 {
+		t.Errorf("Wrong Donchian channel %v", dc)
+	}
+
+	if v := h.Volatility(30); v <= 0 || math.IsNaN(v) {
+		t.Errorf("Wrong volatility %f", v)
+	}
+
+	// Volume grows 100 a day.
+	if trend := h.VolumeTrend(30); math.Abs(trend-100) > 1e-9 {
+		t.Errorf("Wrong volume trend %f", trend)
+	}
+
+	for _, window := range []int{0, -1} {
+		if h.MovingAverage(window) != nil || h.Volatility(window) != 0 || h.VolumeTrend(window) != 0 {
+			t.Errorf("Expected nothing for window %d", window)
+		}
+	}
+
+	if gaps := h.Gaps(); len(gaps) != 1 || gaps[0].Day() != 3 {
+		t.Errorf("Wrong gaps %v", gaps)
+	}
+}
+
+func TestMarketHistoryMerge(t *testing.T) {
+	c := &MarketTypeHistoryCollectionV1{}
+	if err := json.Unmarshal([]byte(testMarketHistory), c); err != nil {
+		t.Fatalf("Error decoding history %v", err)
+	}
+	h := c.History()
+
+	// Store locally and reload.
+	buf, err := json.Marshal(h[:2])
+	if err != nil {
+		t.Fatalf("Error encoding history %v", err)
+	}
+	// Dates keep the RFC 3339 encoding of time.Time.
+	if !strings.Contains(string(buf), `"2016-01-01T00:00:00Z"`) {
+		t.Errorf("Wrong date encoding %s", buf)
+	}
+	var stored MarketHistory
+	if err := json.Unmarshal(buf, &stored); err != nil {
+		t.Fatalf("Error decoding stored history %v", err)
+	}
+
+	merged, added := stored.Merge(h)
+	if added != 2 || len(merged) != 4 {
+		t.Errorf("Merged %d days into %d", added, len(merged))
+	}
+	if _, added = merged.Merge(h); added != 0 {
+		t.Errorf("Merge added %d duplicate days", added)
+	}
+}
@@ -0,0 +1,146 @@
+package eveapi
+
+import (
+	"sort"
+	"time"
+)
+
+// Expires returns the time the order expires.
+func (o *MarketOrderSlimV1) Expires() time.Time {
+	return o.Issued.AddDate(0, 0, int(o.Duration))
+}
+
+// MarketOrderSnapshot joins the pages of a regional order snapshot.
+func MarketOrderSnapshot(pages ...*MarketOrderCollectionSlimV1) []MarketOrderSlimV1 {
+	var orders []MarketOrderSlimV1
+	for _, p := range pages {
+		orders = append(orders, p.Items...)
+	}
+	return orders
+}
+
+// OrderChangeType is the kind of change seen on an order between snapshots.
+type OrderChangeType int
+
+const (
+	OrderNew OrderChangeType = iota
+	OrderPriceModified
+	OrderPartiallyFilled
+	// OrderClosed is an order gone before its expiry. It was either filled or
+	// cancelled, which the snapshots cannot tell apart.
+	OrderClosed
+	OrderExpired
+)
+
+func (t OrderChangeType) String() string {
+	switch t {
+	case OrderNew:
+		return "new"
+	case OrderPriceModified:
+		return "price modified"
+	case OrderPartiallyFilled:
+		return "partially filled"
+	case OrderClosed:
+		return "filled or cancelled"
+	case OrderExpired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+// OrderChange is a change to an order between two snapshots.
+type OrderChange struct {
+	Type OrderChangeType
+	// Order is the current order, or the last seen order if it is gone.
+	Order MarketOrderSlimV1
+	// Previous is the order in the previous snapshot, nil for new orders.
+	Previous *MarketOrderSlimV1
+	// VolumeTraded is the volume that was filled between the snapshots.
+	VolumeTraded int64
+	// VolumeClosed is the volume left on a closed order, which may or may not
+	// have traded.
+	VolumeClosed int64
+}
+
+// DiffMarketOrders compares two snapshots of orders taken before at.
+//
+// An order that disappears before its expiry is classed as closed with its volume in
+// VolumeClosed rather than VolumeTraded, since the snapshots cannot tell a filled
+// order from a cancelled one. An order that disappears after its expiry is classed as
+// expired and trades nothing. A price modification that also
+// loses volume is reported as a price modification with the volume traded.
+func DiffMarketOrders(previous, current []MarketOrderSlimV1, at time.Time) []OrderChange {
+	var changes []OrderChange
+
+	before := make(map[int64]*MarketOrderSlimV1, len(previous))
+	for i := range previous {
+		before[previous[i].ID] = &previous[i]
+	}
+
+	seen := make(map[int64]bool, len(current))
+	for _, o := range current {
+		seen[o.ID] = true
+		p, ok := before[o.ID]
+		if !ok {
+			changes = append(changes, OrderChange{Type: OrderNew, Order: o})
+			continue
+		}
+
+		traded := p.Volume - o.Volume
+		if traded < 0 {
+			traded = 0
+		}
+		switch {
+		case o.Price != p.Price:
+			changes = append(changes, OrderChange{Type: OrderPriceModified, Order: o, Previous: p, VolumeTraded: traded})
+		case traded > 0:
+			changes = append(changes, OrderChange{Type: OrderPartiallyFilled, Order: o, Previous: p, VolumeTraded: traded})
+		}
+	}
+
+	for i := range previous {
+		p := &previous[i]
+		if seen[p.ID] {
+			continue
+		}
+		if at.Before(p.Expires()) {
+			changes = append(changes, OrderChange{Type: OrderClosed, Order: *p, Previous: p, VolumeClosed: p.Volume})
+		} else {
+			changes = append(changes, OrderChange{Type: OrderExpired, Order: *p, Previous: p})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Order.ID < changes[j].Order.ID })
+	return changes
+}
+
+// TradedVolume is the volume of a type traded between two snapshots.
+type TradedVolume struct {
+	Bought int64 // Filled from sell orders
+	Sold   int64 // Filled into buy orders
+	// ClosedSell and ClosedBuy are the volumes of orders closed before expiry.
+	// Adding them to Bought and Sold gives an upper bound on the volume traded.
+	ClosedSell int64
+	ClosedBuy  int64
+}
+
+// TradedVolumes estimates the volume traded per typeID from order changes. Bought
+// and Sold only count volume seen to fill, so they are a lower bound.
+func TradedVolumes(changes []OrderChange) map[int64]TradedVolume {
+	volumes := make(map[int64]TradedVolume)
+	for _, c := range changes {
+		if c.VolumeTraded == 0 && c.VolumeClosed == 0 {
+			continue
+		}
+		v := volumes[c.Order.Type]
+		if c.Order.Buy {
+			v.Sold += c.VolumeTraded
+			v.ClosedBuy += c.VolumeClosed
+		} else {
+			v.Bought += c.VolumeTraded
+			v.ClosedSell += c.VolumeClosed
+		}
+		volumes[c.Order.Type] = v
+	}
+	return volumes
+}
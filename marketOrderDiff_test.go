@@ -0,0 +1,66 @@
+package eveapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffMarketOrders(t *testing.T) {
+	issued := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	order := func(id int64, buy bool, price float64, volume int64, duration int64) MarketOrderSlimV1 {
+		return MarketOrderSlimV1{
+			ID:       id,
+			Type:     34,
+			Buy:      buy,
+			Price:    price,
+			Volume:   volume,
+			Duration: duration,
+			Issued:   EVETime{issued},
+		}
+	}
+
+	previous := []MarketOrderSlimV1{
+		order(1, false, 5, 100, 90), // partially filled
+		order(2, false, 6, 100, 90), // price modified
+		order(3, true, 4, 50, 90),   // buy filled or cancelled
+		order(4, true, 4, 50, 1),    // expired
+		order(5, false, 7, 10, 90),  // unchanged
+		order(7, false, 8, 30, 90),  // sell filled or cancelled
+	}
+	current := []MarketOrderSlimV1{
+		order(1, false, 5, 60, 90),
+		order(2, false, 5.5, 100, 90),
+		order(5, false, 7, 10, 90),
+		order(6, true, 4, 10, 90), // new
+	}
+
+	changes := DiffMarketOrders(previous, current, issued.AddDate(0, 0, 2))
+	expected := []struct {
+		id     int64
+		t      OrderChangeType
+		traded int64
+		closed int64
+	}{
+		{1, OrderPartiallyFilled, 40, 0},
+		{2, OrderPriceModified, 0, 0},
+		{3, OrderClosed, 0, 50},
+		{4, OrderExpired, 0, 0},
+		{6, OrderNew, 0, 0},
+		{7, OrderClosed, 0, 30},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d", len(expected), len(changes))
+	}
+	for i, e := range expected {
+		c := changes[i]
+		if c.Order.ID != e.id || c.Type != e.t || c.VolumeTraded != e.traded || c.VolumeClosed != e.closed {
+			t.Errorf("Change %d is %d %s %d/%d, expected %d %s %d/%d", i, c.Order.ID, c.Type, c.VolumeTraded, c.VolumeClosed, e.id, e.t, e.traded, e.closed)
+		}
+	}
+
+	// Closed orders are kept out of the traded volume.
+	v := TradedVolumes(changes)[34]
+	if v.Bought != 40 || v.Sold != 0 || v.ClosedSell != 30 || v.ClosedBuy != 50 {
+		t.Errorf("Wrong traded volumes %+v", v)
+	}
+}
@@ -0,0 +1,64 @@
+package eveapi
+
+// MarketPrice is the price of a type in a region.
+type MarketPrice struct {
+	Buy  float64 // Highest buy order
+	Sell float64 // Lowest sell order
+}
+
+// MarketPrices maps typeIDs to their price in a region.
+type MarketPrices map[int64]MarketPrice
+
+// AddOrders prices types from the best buy and sell orders on a page of market orders.
+func (m MarketPrices) AddOrders(orders *MarketOrderCollectionSlimV1) {
+	for _, o := range orders.Items {
+		p := m[o.Type]
+		if o.Buy {
+			if o.Price > p.Buy {
+				p.Buy = o.Price
+			}
+		} else if p.Sell == 0 || o.Price < p.Sell {
+			p.Sell = o.Price
+		}
+		m[o.Type] = p
+	}
+}
+
+// AddHistory prices a type from the average price of the latest day of market history.
+// Prices already known from orders are kept.
+func (m MarketPrices) AddHistory(history *MarketTypeHistoryCollectionV1) {
+	if len(history.Items) == 0 {
+		return
+	}
+	latest := history.Items[0]
+	for _, i := range history.Items {
+		if i.Date.After(latest.Date.Time) {
+			latest = i
+		}
+	}
+
+	p := m[history.TypeID]
+	if p.Buy == 0 {
+		p.Buy = latest.AvgPrice
+	}
+	if p.Sell == 0 {
+		p.Sell = latest.AvgPrice
+	}
+	m[history.TypeID] = p
+}
+
+// MarketPricesV1ByID prices every type with orders in a region by walking all pages of orders.
+func (c *EVEAPIClient) MarketPricesV1ByID(regionID int64) (MarketPrices, error) {
+	m := make(MarketPrices)
+	orders, err := c.MarketOrdersSlimV1ByID(regionID, 1)
+	for {
+		if err != nil {
+			return nil, err
+		}
+		if orders == nil {
+			return m, nil
+		}
+		m.AddOrders(orders)
+		orders, err = orders.NextPage()
+	}
+}
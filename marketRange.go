@@ -0,0 +1,105 @@
+package eveapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// MarketOrderRange is the distance from its station a buy order can be filled from.
+// Values from 1 to 40 are a number of jumps.
+type MarketOrderRange int
+
+const (
+	RangeStation     MarketOrderRange = -1
+	RangeSolarSystem MarketOrderRange = 0
+	RangeRegion      MarketOrderRange = 32767
+)
+
+// ParseMarketOrderRange converts a CREST order range to a MarketOrderRange.
+func ParseMarketOrderRange(s string) (MarketOrderRange, error) {
+	switch s {
+	case "station":
+		return RangeStation, nil
+	case "solarsystem":
+		return RangeSolarSystem, nil
+	case "region":
+		return RangeRegion, nil
+	}
+	jumps, err := strconv.Atoi(s)
+	if err != nil || jumps < 1 || jumps > 40 {
+		return 0, fmt.Errorf("invalid order range %q", s)
+	}
+	return MarketOrderRange(jumps), nil
+}
+
+func (r MarketOrderRange) String() string {
+	switch r {
+	case RangeStation:
+		return "station"
+	case RangeSolarSystem:
+		return "solarsystem"
+	case RangeRegion:
+		return "region"
+	}
+	return strconv.Itoa(int(r))
+}
+
+// OrderRange returns the typed range of the order.
+func (o *MarketOrderSlimV1) OrderRange() (MarketOrderRange, error) {
+	return ParseMarketOrderRange(o.Range)
+}
+
+var errNotBuyOrder = errors.New("order is not a buy order")
+
+// ReachableFrom reports whether a buy order can be filled by selling at stationID.
+func (o *MarketOrderSlimV1) ReachableFrom(stationID int64, g *JumpGraph) (bool, error) {
+	if !o.Buy {
+		return false, errNotBuyOrder
+	}
+	r, err := o.OrderRange()
+	if err != nil {
+		return false, err
+	}
+	if r == RangeStation {
+		return o.StationID == stationID, nil
+	}
+
+	orderSystem, ok := g.StationSolarSystemID(o.StationID)
+	if !ok {
+		return false, fmt.Errorf("unknown station %d", o.StationID)
+	}
+	fromSystem, ok := g.StationSolarSystemID(stationID)
+	if !ok {
+		return false, fmt.Errorf("unknown station %d", stationID)
+	}
+
+	if r == RangeSolarSystem {
+		return orderSystem == fromSystem, nil
+	}
+
+	// Orders never reach past their region, however close the gate.
+	orderRegion, _ := g.SolarSystemRegionID(orderSystem)
+	fromRegion, _ := g.SolarSystemRegionID(fromSystem)
+	if orderRegion != fromRegion {
+		return false, nil
+	}
+	if r == RangeRegion {
+		return true, nil
+	}
+
+	_, ok = g.jumpsWithin(orderSystem, fromSystem, int(r))
+	return ok, nil
+}
+
+// ReachableBuyOrders returns the buy orders that can be filled by selling at stationID.
+// Sell orders and orders in unknown stations are dropped.
+func ReachableBuyOrders(orders []MarketOrderSlimV1, stationID int64, g *JumpGraph) []MarketOrderSlimV1 {
+	var reachable []MarketOrderSlimV1
+	for i := range orders {
+		if ok, err := orders[i].ReachableFrom(stationID, g); err == nil && ok {
+			reachable = append(reachable, orders[i])
+		}
+	}
+	return reachable
+}
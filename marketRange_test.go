@@ -0,0 +1,87 @@
+package eveapi
+
+import (
+	"bytes"
+	"testing"
+)
+
+// A line of systems 1-2-3-4 in region 10 and system 5 in region 11, off system 4.
+func testJumpGraph() *JumpGraph {
+	g := NewJumpGraph()
+	for id := int64(1); id <= 4; id++ {
+		g.AddSolarSystem(id, 10, 1.0)
+		g.AddStation(100+id, id)
+	}
+	g.AddSolarSystem(5, 11, 0.1)
+	g.AddStation(105, 5)
+	g.AddJump(1, 2)
+	g.AddJump(2, 3)
+	g.AddJump(3, 4)
+	g.AddJump(4, 5)
+	return g
+}
+
+func TestParseMarketOrderRange(t *testing.T) {
+	for s, r := range map[string]MarketOrderRange{
+		"station":     RangeStation,
+		"solarsystem": RangeSolarSystem,
+		"region":      RangeRegion,
+		"5":           5,
+	} {
+		got, err := ParseMarketOrderRange(s)
+		if err != nil || got != r || got.String() != s {
+			t.Errorf("Range %s parsed as %v %v", s, got, err)
+		}
+	}
+	if _, err := ParseMarketOrderRange("41"); err == nil {
+		t.Errorf("Range 41 should be invalid")
+	}
+}
+
+func TestReachableBuyOrders(t *testing.T) {
+	g := testJumpGraph()
+	orders := []MarketOrderSlimV1{
+		{ID: 1, Buy: true, Range: "station", StationID: 101},
+		{ID: 2, Buy: true, Range: "solarsystem", StationID: 102},
+		{ID: 3, Buy: true, Range: "2", StationID: 103},
+		{ID: 4, Buy: true, Range: "1", StationID: 103},
+		{ID: 5, Buy: true, Range: "region", StationID: 104},
+		{ID: 6, Buy: true, Range: "region", StationID: 105},
+		{ID: 7, Buy: false, Range: "region", StationID: 101},
+		// In range through the gate from region 11 but not in the same region.
+		{ID: 8, Buy: true, Range: "5", StationID: 105},
+	}
+
+	reachable := ReachableBuyOrders(orders, 101, g)
+	expected := []int64{1, 3, 5}
+	if len(reachable) != len(expected) {
+		t.Fatalf("Expected %d reachable orders, got %v", len(expected), reachable)
+	}
+	for i, id := range expected {
+		if reachable[i].ID != id {
+			t.Errorf("Order %d should be reachable, got %d", id, reachable[i].ID)
+		}
+	}
+
+	// Across the gate between regions even one jump is out of range.
+	cross := MarketOrderSlimV1{Buy: true, Range: "1", StationID: 104}
+	if ok, err := cross.ReachableFrom(105, g); err != nil || ok {
+		t.Errorf("Order reachable from another region %v %v", ok, err)
+	}
+	if ok, err := cross.ReachableFrom(103, g); err != nil || !ok {
+		t.Errorf("Order not reachable in its region %v %v", ok, err)
+	}
+
+	// Reload the graph and get the same answer.
+	buf := &bytes.Buffer{}
+	if err := g.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadJumpGraph(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jumps, ok := loaded.Jumps(1, 5); !ok || jumps != 4 {
+		t.Errorf("Loaded graph has %d jumps from 1 to 5", jumps)
+	}
+}
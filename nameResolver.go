@@ -0,0 +1,285 @@
+package eveapi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antihax/eveapi/internal/workers"
+)
+
+// ErrNameNotFound is returned for IDs and names the XML API does not know.
+var ErrNameNotFound = errors.New("ID or name not found")
+
+// NameResolver resolves character, corporation and alliance IDs to names and back
+// in batches through the XML API. Results are cached, and lookups already in flight
+// are shared between callers instead of being requested again.
+type NameResolver struct {
+	client *EVEAPIClient
+
+	// TTL is how long resolved IDs and names are cached.
+	TTL time.Duration
+	// UnknownTTL is how long unknown IDs and names are cached.
+	UnknownTTL time.Duration
+	// Concurrency is the number of batches requested at once.
+	Concurrency int
+
+	mu    sync.Mutex
+	names map[int64]*nameLookup
+	ids   map[string]*nameLookup
+}
+
+// nameLookup is one ID or name, in flight until done is closed.
+type nameLookup struct {
+	done    chan struct{}
+	id      int64
+	name    string
+	err     error
+	expires time.Time
+}
+
+func NewNameResolver(c *EVEAPIClient) *NameResolver {
+	return &NameResolver{
+		client:      c,
+		TTL:         7 * 24 * time.Hour,
+		UnknownTTL:  time.Hour,
+		Concurrency: 4,
+		names:       make(map[int64]*nameLookup),
+		ids:         make(map[string]*nameLookup),
+	}
+}
+
+// usable reports whether the lookup is in flight or a cached result that has not expired.
+func (l *nameLookup) usable(now time.Time) bool {
+	select {
+	case <-l.done:
+		return now.Before(l.expires)
+	default:
+		return true
+	}
+}
+
+func (l *nameLookup) complete(id int64, name string, err error, ttl time.Duration) {
+	l.id = id
+	l.name = name
+	l.err = err
+	if ttl > 0 {
+		l.expires = time.Now().Add(ttl)
+	}
+	close(l.done)
+}
+
+func completedLookup(id int64, name string, ttl time.Duration) *nameLookup {
+	l := &nameLookup{done: make(chan struct{})}
+	l.complete(id, name, nil, ttl)
+	return l
+}
+
+// Name resolves a single ID.
+func (r *NameResolver) Name(id int64) (string, error) {
+	names, errs := r.Names([]int64{id})
+	return names[id], errs[id]
+}
+
+// ID resolves a single name, ignoring case.
+func (r *NameResolver) ID(name string) (int64, error) {
+	ids, errs := r.IDs([]string{name})
+	return ids[name], errs[name]
+}
+
+// Names resolves IDs to names. IDs that could not be resolved are in the error
+// map instead, with ErrNameNotFound if the ID does not exist.
+func (r *NameResolver) Names(ids []int64) (map[int64]string, map[int64]error) {
+	now := time.Now()
+	lookups := make(map[int64]*nameLookup)
+	var fetch []*nameLookup
+
+	r.mu.Lock()
+	for _, id := range ids {
+		if _, ok := lookups[id]; ok {
+			continue
+		}
+		l := r.names[id]
+		if l == nil || !l.usable(now) {
+			l = &nameLookup{done: make(chan struct{}), id: id}
+			r.names[id] = l
+			fetch = append(fetch, l)
+		}
+		lookups[id] = l
+	}
+	r.mu.Unlock()
+
+	batches := (len(fetch) + characterNameBatchSize - 1) / characterNameBatchSize
+	workers.Run(r.Concurrency, batches, func(i int) error {
+		end := (i + 1) * characterNameBatchSize
+		if end > len(fetch) {
+			end = len(fetch)
+		}
+		r.fetchNames(fetch[i*characterNameBatchSize : end])
+		return nil
+	})
+
+	names := make(map[int64]string)
+	errs := make(map[int64]error)
+	for id, l := range lookups {
+		<-l.done
+		if l.err != nil {
+			errs[id] = l.err
+		} else {
+			names[id] = l.name
+		}
+	}
+	return names, errs
+}
+
+// IDs resolves names to IDs, ignoring case. Names that could not be resolved are
+// in the error map instead, with ErrNameNotFound if the name does not exist.
+func (r *NameResolver) IDs(names []string) (map[string]int64, map[string]error) {
+	now := time.Now()
+	lookups := make(map[string]*nameLookup)
+	var fetch []*nameLookup
+
+	r.mu.Lock()
+	for _, name := range names {
+		if _, ok := lookups[name]; ok {
+			continue
+		}
+		key := strings.ToLower(name)
+		l := r.ids[key]
+		if l == nil || !l.usable(now) {
+			l = &nameLookup{done: make(chan struct{}), name: name}
+			r.ids[key] = l
+			fetch = append(fetch, l)
+		}
+		lookups[name] = l
+	}
+	r.mu.Unlock()
+
+	batches := (len(fetch) + characterNameBatchSize - 1) / characterNameBatchSize
+	workers.Run(r.Concurrency, batches, func(i int) error {
+		end := (i + 1) * characterNameBatchSize
+		if end > len(fetch) {
+			end = len(fetch)
+		}
+		r.fetchIDs(fetch[i*characterNameBatchSize : end])
+		return nil
+	})
+
+	ids := make(map[string]int64)
+	errs := make(map[string]error)
+	for name, l := range lookups {
+		<-l.done
+		if l.err != nil {
+			errs[name] = l.err
+		} else {
+			ids[name] = l.id
+		}
+	}
+	return ids, errs
+}
+
+// The XML API rejects a whole batch if any ID in it is invalid, so rejected batches
+// are split until the invalid IDs are found.
+func batchRejected(err error) bool {
+	s, ok := err.(*StatusError)
+	return ok && s.StatusCode >= 400 && s.StatusCode < 500
+}
+
+func (r *NameResolver) fetchNames(batch []*nameLookup) {
+	ids := make([]int64, len(batch))
+	for i, l := range batch {
+		ids[i] = l.id
+	}
+
+	res, err := r.client.CharacterNameXML(ids)
+	if err != nil {
+		switch {
+		case batchRejected(err) && len(batch) > 1:
+			r.fetchNames(batch[:len(batch)/2])
+			r.fetchNames(batch[len(batch)/2:])
+		case batchRejected(err):
+			batch[0].complete(batch[0].id, "", ErrNameNotFound, r.UnknownTTL)
+		default:
+			for _, l := range batch {
+				l.complete(l.id, "", err, 0)
+			}
+		}
+		return
+	}
+
+	found := make(map[int64]string)
+	for _, e := range res.Entities {
+		if e.Name != "" {
+			found[e.CharacterID] = e.Name
+		}
+	}
+	for _, l := range batch {
+		if name, ok := found[l.id]; ok {
+			l.complete(l.id, name, nil, r.TTL)
+			r.cacheID(l.id, name)
+		} else {
+			l.complete(l.id, "", ErrNameNotFound, r.UnknownTTL)
+		}
+	}
+}
+
+func (r *NameResolver) fetchIDs(batch []*nameLookup) {
+	names := make([]string, len(batch))
+	for i, l := range batch {
+		names[i] = l.name
+	}
+
+	res, err := r.client.CharacterIDXML(names)
+	if err != nil {
+		switch {
+		case batchRejected(err) && len(batch) > 1:
+			r.fetchIDs(batch[:len(batch)/2])
+			r.fetchIDs(batch[len(batch)/2:])
+		case batchRejected(err):
+			batch[0].complete(0, batch[0].name, ErrNameNotFound, r.UnknownTTL)
+		default:
+			for _, l := range batch {
+				l.complete(0, l.name, err, 0)
+			}
+		}
+		return
+	}
+
+	found := make(map[string]int64)
+	canonical := make(map[string]string)
+	for _, e := range res.Entities {
+		if e.CharacterID != 0 {
+			found[strings.ToLower(e.Name)] = e.CharacterID
+			canonical[strings.ToLower(e.Name)] = e.Name
+		}
+	}
+	for _, l := range batch {
+		key := strings.ToLower(l.name)
+		if id, ok := found[key]; ok {
+			l.complete(id, canonical[key], nil, r.TTL)
+			r.cacheName(id, canonical[key])
+		} else {
+			l.complete(0, l.name, ErrNameNotFound, r.UnknownTTL)
+		}
+	}
+}
+
+// cacheID caches the reverse lookup of a resolved name, unless one is in flight.
+func (r *NameResolver) cacheID(id int64, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := strings.ToLower(name)
+	if l := r.ids[key]; l == nil || !l.usable(time.Now()) {
+		r.ids[key] = completedLookup(id, name, r.TTL)
+	}
+}
+
+// cacheName caches the reverse lookup of a resolved ID, unless one is in flight.
+func (r *NameResolver) cacheName(id int64, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l := r.names[id]; l == nil || !l.usable(time.Now()) {
+		r.names[id] = completedLookup(id, name, r.TTL)
+	}
+}
@@ -0,0 +1,70 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNameResolver(t *testing.T) {
+	known := map[string]string{"1": "Alpha", "2": "Beta"}
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var rows []string
+		switch r.URL.Path {
+		case "/eve/CharacterName.xml.aspx":
+			for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+				if id == "666" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `<eveapi version="2"><error code="122">Invalid or missing list of IDs</error></eveapi>`)
+					return
+				}
+				if name, ok := known[id]; ok {
+					rows = append(rows, fmt.Sprintf(`<row name="%s" characterID="%s" />`, name, id))
+				}
+			}
+		case "/eve/CharacterID.xml.aspx":
+			for _, name := range strings.Split(r.URL.Query().Get("names"), ",") {
+				id := "0"
+				for k, v := range known {
+					if strings.EqualFold(v, name) {
+						id = k
+					}
+				}
+				rows = append(rows, fmt.Sprintf(`<row name="%s" characterID="%s" />`, name, id))
+			}
+		}
+		fmt.Fprintf(w, `<eveapi version="2"><result><rowset name="characters">%s</rowset></result></eveapi>`, strings.Join(rows, ""))
+	}))
+	defer srv.Close()
+
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{XML: srv.URL + "/"}, userAgent: USER_AGENT}
+	r := NewNameResolver(c)
+
+	names, errs := r.Names([]int64{1, 2, 3, 666, 1})
+	if len(names) != 2 || names[1] != "Alpha" || names[2] != "Beta" {
+		t.Errorf("Wrong names %v", names)
+	}
+	if len(errs) != 2 || errs[3] != ErrNameNotFound || errs[666] != ErrNameNotFound {
+		t.Errorf("Wrong errors %v", errs)
+	}
+
+	// Everything is cached, including the reverse lookups.
+	before := atomic.LoadInt32(&requests)
+	r.Names([]int64{1, 2, 3, 666})
+	if id, err := r.ID("alpha"); err != nil || id != 1 {
+		t.Errorf("Expected alpha to be 1, got %d %v", id, err)
+	}
+	if after := atomic.LoadInt32(&requests); after != before {
+		t.Errorf("Cached lookups made %d requests", after-before)
+	}
+
+	ids, idErrs := r.IDs([]string{"BETA", "Nobody"})
+	if ids["BETA"] != 2 || idErrs["Nobody"] != ErrNameNotFound {
+		t.Errorf("Wrong IDs %v %v", ids, idErrs)
+	}
+}
@@ -0,0 +1,59 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Most IDs or names accepted by one CharacterName or CharacterID call.
+const characterNameBatchSize = 250
+
+// CharacterNameXML resolves character, corporation and alliance IDs to names.
+type CharacterNameXML struct {
+	xmlAPIFrame
+	Entities []struct {
+		Name        string `xml:"name,attr"`
+		CharacterID int64  `xml:"characterID,attr"`
+	} `xml:"result>rowset>row"`
+}
+
+// CharacterNameXML queries the XML API for the names of up to 250 IDs.
+func (c *EVEAPIClient) CharacterNameXML(ids []int64) (*CharacterNameXML, error) {
+	w := &CharacterNameXML{}
+
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = fmt.Sprintf("%d", id)
+	}
+
+	url := c.base.XML + "eve/CharacterName.xml.aspx?ids=" + strings.Join(list, ",")
+	_, err := c.doXML("GET", url, nil, w, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CharacterIDXML resolves character, corporation and alliance names to IDs.
+// Unknown names have an ID of zero.
+type CharacterIDXML struct {
+	xmlAPIFrame
+	Entities []struct {
+		Name        string `xml:"name,attr"`
+		CharacterID int64  `xml:"characterID,attr"`
+	} `xml:"result>rowset>row"`
+}
+
+// CharacterIDXML queries the XML API for the IDs of up to 250 names.
+func (c *EVEAPIClient) CharacterIDXML(names []string) (*CharacterIDXML, error) {
+	w := &CharacterIDXML{}
+
+	list := url.QueryEscape(strings.Join(names, ","))
+	href := c.base.XML + "eve/CharacterID.xml.aspx?names=" + list
+	_, err := c.doXML("GET", href, nil, w, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,225 @@
+package eveapi
+
+import "sort"
+
+// StationLocator finds the solar system of a station.
+type StationLocator interface {
+	StationSolarSystemID(stationID int64) (int64, bool)
+}
+
+// OrderBookKey identifies the order book of a type in a region.
+type OrderBookKey struct {
+	RegionID int64
+	TypeID   int64
+}
+
+// OrderBook holds the buy and sell orders of a type in a region.
+type OrderBook struct {
+	RegionID int64
+	TypeID   int64
+	Bids     []MarketOrderSlimV1 // Buy orders, highest price first
+	Asks     []MarketOrderSlimV1 // Sell orders, lowest price first
+}
+
+// NewOrderBook builds the order book of a type from a list of orders.
+// Orders for other types are ignored.
+func NewOrderBook(regionID int64, typeID int64, orders []MarketOrderSlimV1) *OrderBook {
+	b := &OrderBook{RegionID: regionID, TypeID: typeID}
+	for _, o := range orders {
+		if o.Type != typeID {
+			continue
+		}
+		if o.Buy {
+			b.Bids = append(b.Bids, o)
+		} else {
+			b.Asks = append(b.Asks, o)
+		}
+	}
+	b.sort()
+	return b
+}
+
+// NewOrderBooks builds an order book for every type in pages of regional orders.
+func NewOrderBooks(pages ...*MarketOrderCollectionSlimV1) map[OrderBookKey]*OrderBook {
+	books := make(map[OrderBookKey]*OrderBook)
+	for _, page := range pages {
+		for _, o := range page.Items {
+			key := OrderBookKey{page.RegionID, o.Type}
+			b := books[key]
+			if b == nil {
+				b = &OrderBook{RegionID: page.RegionID, TypeID: o.Type}
+				books[key] = b
+			}
+			if o.Buy {
+				b.Bids = append(b.Bids, o)
+			} else {
+				b.Asks = append(b.Asks, o)
+			}
+		}
+	}
+	for _, b := range books {
+		b.sort()
+	}
+	return books
+}
+
+func (b *OrderBook) sort() {
+	sort.SliceStable(b.Bids, func(i, j int) bool { return b.Bids[i].Price > b.Bids[j].Price })
+	sort.SliceStable(b.Asks, func(i, j int) bool { return b.Asks[i].Price < b.Asks[j].Price })
+}
+
+// BestBid returns the highest buy order.
+func (b *OrderBook) BestBid() (MarketOrderSlimV1, bool) {
+	if len(b.Bids) == 0 {
+		return MarketOrderSlimV1{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestAsk returns the lowest sell order.
+func (b *OrderBook) BestAsk() (MarketOrderSlimV1, bool) {
+	if len(b.Asks) == 0 {
+		return MarketOrderSlimV1{}, false
+	}
+	return b.Asks[0], true
+}
+
+// Spread returns the difference between the best ask and best bid.
+func (b *OrderBook) Spread() (float64, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return 0, false
+	}
+	ask, ok := b.BestAsk()
+	if !ok {
+		return 0, false
+	}
+	return ask.Price - bid.Price, true
+}
+
+// Orders within percent of the best price.
+func (b *OrderBook) bidsWithin(percent float64) []MarketOrderSlimV1 {
+	if len(b.Bids) == 0 {
+		return nil
+	}
+	limit := b.Bids[0].Price * (1 - percent/100)
+	i := sort.Search(len(b.Bids), func(i int) bool { return b.Bids[i].Price < limit })
+	return b.Bids[:i]
+}
+
+func (b *OrderBook) asksWithin(percent float64) []MarketOrderSlimV1 {
+	if len(b.Asks) == 0 {
+		return nil
+	}
+	limit := b.Asks[0].Price * (1 + percent/100)
+	i := sort.Search(len(b.Asks), func(i int) bool { return b.Asks[i].Price > limit })
+	return b.Asks[:i]
+}
+
+func orderVolume(orders []MarketOrderSlimV1) int64 {
+	var v int64
+	for _, o := range orders {
+		v += o.Volume
+	}
+	return v
+}
+
+func weightedPrice(orders []MarketOrderSlimV1) float64 {
+	var total float64
+	var volume int64
+	for _, o := range orders {
+		total += o.Price * float64(o.Volume)
+		volume += o.Volume
+	}
+	if volume == 0 {
+		return 0
+	}
+	return total / float64(volume)
+}
+
+// Depth returns the volume of buy and sell orders within percent of the best prices.
+func (b *OrderBook) Depth(percent float64) (bidVolume int64, askVolume int64) {
+	return orderVolume(b.bidsWithin(percent)), orderVolume(b.asksWithin(percent))
+}
+
+// WeightedPrices returns the volume weighted price of buy and sell orders within
+// percent of the best prices.
+func (b *OrderBook) WeightedPrices(percent float64) (bid float64, ask float64) {
+	return weightedPrice(b.bidsWithin(percent)), weightedPrice(b.asksWithin(percent))
+}
+
+// BuyCost returns the cost of buying quantity units from the sell orders now, and the
+// quantity that could be filled.
+func (b *OrderBook) BuyCost(quantity int64) (cost float64, filled int64) {
+	for _, o := range b.Asks {
+		if filled >= quantity {
+			break
+		}
+		n := o.Volume
+		if n > quantity-filled {
+			n = quantity - filled
+		}
+		cost += float64(n) * o.Price
+		filled += n
+	}
+	return cost, filled
+}
+
+// SellProceeds returns the ISK from selling quantity units into the buy orders now,
+// and the quantity that could be sold. Buy orders with a minimum volume above the
+// remaining quantity are skipped.
+func (b *OrderBook) SellProceeds(quantity int64) (proceeds float64, filled int64) {
+	for _, o := range b.Bids {
+		if filled >= quantity {
+			break
+		}
+		n := o.Volume
+		if n > quantity-filled {
+			n = quantity - filled
+		}
+		if n < o.MinVolume {
+			continue
+		}
+		proceeds += float64(n) * o.Price
+		filled += n
+	}
+	return proceeds, filled
+}
+
+// Filter returns an order book with only the orders matching keep.
+func (b *OrderBook) Filter(keep func(o *MarketOrderSlimV1) bool) *OrderBook {
+	n := &OrderBook{RegionID: b.RegionID, TypeID: b.TypeID}
+	for i := range b.Bids {
+		if keep(&b.Bids[i]) {
+			n.Bids = append(n.Bids, b.Bids[i])
+		}
+	}
+	for i := range b.Asks {
+		if keep(&b.Asks[i]) {
+			n.Asks = append(n.Asks, b.Asks[i])
+		}
+	}
+	return n
+}
+
+// FilterStations returns an order book with only the orders in the stations.
+func (b *OrderBook) FilterStations(stationIDs ...int64) *OrderBook {
+	ids := make(map[int64]bool)
+	for _, id := range stationIDs {
+		ids[id] = true
+	}
+	return b.Filter(func(o *MarketOrderSlimV1) bool { return ids[o.StationID] })
+}
+
+// FilterSolarSystems returns an order book with only the orders in the solar systems.
+// Orders in stations unknown to the locator are dropped.
+func (b *OrderBook) FilterSolarSystems(locator StationLocator, solarSystemIDs ...int64) *OrderBook {
+	ids := make(map[int64]bool)
+	for _, id := range solarSystemIDs {
+		ids[id] = true
+	}
+	return b.Filter(func(o *MarketOrderSlimV1) bool {
+		system, ok := locator.StationSolarSystemID(o.StationID)
+		return ok && ids[system]
+	})
+}
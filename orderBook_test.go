@@ -0,0 +1,70 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testMarketOrders = `{
+	"items": [
+		{"buy": true, "price": 100, "volume": 10, "minVolume": 1, "type": 34, "stationID": 60003760, "id": 1},
+		{"buy": true, "price": 98, "volume": 20, "minVolume": 1, "type": 34, "stationID": 60008494, "id": 2},
+		{"buy": true, "price": 50, "volume": 100, "minVolume": 50, "type": 34, "stationID": 60003760, "id": 3},
+		{"buy": false, "price": 110, "volume": 5, "type": 34, "stationID": 60003760, "id": 4},
+		{"buy": false, "price": 105, "volume": 5, "type": 34, "stationID": 60008494, "id": 5},
+		{"buy": false, "price": 200, "volume": 100, "type": 34, "stationID": 60003760, "id": 6},
+		{"buy": false, "price": 5, "volume": 100, "type": 35, "stationID": 60003760, "id": 7}
+	],
+	"regionID": 10000002
+}`
+
+type testStationLocator map[int64]int64
+
+func (t testStationLocator) StationSolarSystemID(stationID int64) (int64, bool) {
+	id, ok := t[stationID]
+	return id, ok
+}
+
+func TestOrderBook(t *testing.T) {
+	page := &MarketOrderCollectionSlimV1{}
+	if err := json.Unmarshal([]byte(testMarketOrders), page); err != nil {
+		t.Fatalf("Error decoding orders %v", err)
+	}
+
+	books := NewOrderBooks(page)
+	if len(books) != 2 {
+		t.Fatalf("Expected 2 books, got %d", len(books))
+	}
+	b := books[OrderBookKey{10000002, 34}]
+
+	if bid, _ := b.BestBid(); bid.ID != 1 {
+		t.Errorf("Wrong best bid %d", bid.ID)
+	}
+	if ask, _ := b.BestAsk(); ask.ID != 5 {
+		t.Errorf("Wrong best ask %d", ask.ID)
+	}
+	if spread, _ := b.Spread(); spread != 5 {
+		t.Errorf("Wrong spread %f", spread)
+	}
+
+	bidVolume, askVolume := b.Depth(5)
+	if bidVolume != 30 || askVolume != 10 {
+		t.Errorf("Wrong depth %d %d", bidVolume, askVolume)
+	}
+	if _, ask := b.WeightedPrices(5); ask != 107.5 {
+		t.Errorf("Wrong weighted ask %f", ask)
+	}
+
+	if cost, filled := b.BuyCost(12); filled != 12 || cost != 5*105+5*110+2*200 {
+		t.Errorf("Wrong buy cost %f for %d", cost, filled)
+	}
+	// The 50 ISK order needs at least 50 units.
+	if proceeds, filled := b.SellProceeds(40); filled != 30 || proceeds != 10*100+20*98 {
+		t.Errorf("Wrong sell proceeds %f for %d", proceeds, filled)
+	}
+
+	jita := b.FilterSolarSystems(testStationLocator{60003760: 30000142, 60008494: 30002187}, 30000142)
+	if len(jita.Bids) != 2 || len(jita.Asks) != 2 {
+		t.Errorf("Wrong orders after filter %d %d", len(jita.Bids), len(jita.Asks))
+	}
+}
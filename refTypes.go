@@ -0,0 +1,195 @@
+package eveapi
+
+import "fmt"
+
+// RefType is the type of a wallet journal entry.
+type RefType int64
+
+const (
+	RefTypePlayerTrading                             RefType = 1
+	RefTypeMarketTransaction                         RefType = 2
+	RefTypePlayerDonation                            RefType = 10
+	RefTypeCorporationPayment                        RefType = 11
+	RefTypeBountyPrize                               RefType = 17
+	RefTypeInsurance                                 RefType = 19
+	RefTypeAgentMissionReward                        RefType = 33
+	RefTypeAgentMissionTimeBonusReward               RefType = 34
+	RefTypeCorporationAccountWithdrawal              RefType = 37
+	RefTypeCorporationDividendPayment                RefType = 38
+	RefTypeMarketEscrow                              RefType = 42
+	RefTypeBrokersFee                                RefType = 46
+	RefTypeTransactionTax                            RefType = 54
+	RefTypeManufacturing                             RefType = 56
+	RefTypeContractAuctionBid                        RefType = 63
+	RefTypeContractPrice                             RefType = 71
+	RefTypeContractBrokersFee                        RefType = 72
+	RefTypeContractSalesTax                          RefType = 73
+	RefTypeBountyPrizes                              RefType = 85
+	RefTypeBountyPrizeCorporationTax                 RefType = 92
+	RefTypeAgentMissionRewardCorporationTax          RefType = 93
+	RefTypeAgentMissionTimeBonusRewardCorporationTax RefType = 94
+	RefTypePlanetaryImportTax                        RefType = 96
+	RefTypePlanetaryExportTax                        RefType = 97
+	RefTypeIndustryJobTax                            RefType = 118
+)
+
+// refTypeNames is the bundled copy of eve/RefTypes.xml.aspx, used for ref types
+// without a name from a live call.
+var refTypeNames = map[RefType]string{
+	0:   "Undefined",
+	1:   "Player Trading",
+	2:   "Market Transaction",
+	3:   "GM Cash Transfer",
+	4:   "ATM Withdraw",
+	5:   "ATM Deposit",
+	6:   "Backward Compatible",
+	7:   "Mission Reward",
+	8:   "Clone Activation",
+	9:   "Inheritance",
+	10:  "Player Donation",
+	11:  "Corporation Payment",
+	12:  "Docking Fee",
+	13:  "Office Rental Fee",
+	14:  "Factory Slot Rental Fee",
+	15:  "Repair Bill",
+	16:  "Bounty",
+	17:  "Bounty Prize",
+	18:  "Agents_temporary",
+	19:  "Insurance",
+	20:  "Mission Expiration",
+	21:  "Mission Completion",
+	22:  "Shares",
+	23:  "Courier Mission Escrow",
+	24:  "Mission Cost",
+	25:  "Agent Miscellaneous",
+	26:  "LP Store",
+	27:  "Agent Location Services",
+	28:  "Agent Donation",
+	29:  "Agent Security Services",
+	30:  "Agent Mission Collateral Paid",
+	31:  "Agent Mission Collateral Refunded",
+	32:  "Agents_preward",
+	33:  "Agent Mission Reward",
+	34:  "Agent Mission Time Bonus Reward",
+	35:  "CSPA",
+	36:  "CSPAOfflineRefund",
+	37:  "Corporation Account Withdrawal",
+	38:  "Corporation Dividend Payment",
+	39:  "Corporation Registration Fee",
+	40:  "Corporation Logo Change Cost",
+	41:  "Release Of Impounded Property",
+	42:  "Market Escrow",
+	43:  "Agent Services Rendered",
+	44:  "Market Fine Paid",
+	45:  "Corporation Liquidation",
+	46:  "Brokers Fee",
+	47:  "Corporation Bulk Payment",
+	48:  "Alliance Registration Fee",
+	49:  "War Fee",
+	50:  "Alliance Maintainance Fee",
+	51:  "Contraband Fine",
+	52:  "Clone Transfer",
+	53:  "Acceleration Gate Fee",
+	54:  "Transaction Tax",
+	55:  "Jump Clone Installation Fee",
+	56:  "Manufacturing",
+	57:  "Researching Technology",
+	58:  "Researching Time Productivity",
+	59:  "Researching Material Productivity",
+	60:  "Copying",
+	61:  "Duplicating",
+	62:  "Reverse Engineering",
+	63:  "Contract Auction Bid",
+	64:  "Contract Auction Bid Refund",
+	65:  "Contract Collateral",
+	66:  "Contract Reward Refund",
+	67:  "Contract Auction Sold",
+	68:  "Contract Reward",
+	69:  "Contract Collateral Refund",
+	70:  "Contract Collateral Payout",
+	71:  "Contract Price",
+	72:  "Contract Brokers Fee",
+	73:  "Contract Sales Tax",
+	74:  "Contract Deposit",
+	75:  "Contract Deposit Sales Tax",
+	76:  "Secure EVE Time Code Exchange",
+	77:  "Contract Auction Bid (corp)",
+	78:  "Contract Collateral Deposited (corp)",
+	79:  "Contract Price Payment (corp)",
+	80:  "Contract Brokers Fee (corp)",
+	81:  "Contract Deposit (corp)",
+	82:  "Contract Deposit Refund",
+	83:  "Contract Reward Deposited",
+	84:  "Contract Reward Deposited (corp)",
+	85:  "Bounty Prizes",
+	86:  "Advertisement Listing Fee",
+	87:  "Medal Creation",
+	88:  "Medal Issued",
+	89:  "Betting",
+	90:  "DNA Modification Fee",
+	91:  "Sovereignty bill",
+	92:  "Bounty Prize Corporation Tax",
+	93:  "Agent Mission Reward Corporation Tax",
+	94:  "Agent Mission Time Bonus Reward Corporation Tax",
+	95:  "Upkeep adjustment fee",
+	96:  "Planetary Import Tax",
+	97:  "Planetary Export Tax",
+	98:  "Planetary Construction",
+	99:  "Corporate Reward Payout",
+	100: "Minigame Betting",
+	101: "Bounty Surcharge",
+	102: "Contract Reversal",
+	103: "Corporate Reward Tax",
+	104: "Minigame Buy-In",
+	105: "Office Upgrade Fee",
+	106: "Store Purchase",
+	107: "Store Purchase Refund",
+	108: "PLEX sold for Aurum",
+	109: "Lottery Give Away",
+	110: "Minigame House Cut",
+	111: "Aurum Token exchanged for AUR",
+	112: "Datacore Fee",
+	113: "War Surrender Fee",
+	114: "War Ally Contract",
+	115: "Bounty Reimbursement",
+	116: "Kill Right",
+	117: "Security Processing Fee",
+	118: "Industry Job Tax",
+}
+
+func (r RefType) String() string {
+	if name, ok := refTypeNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("RefType(%d)", int64(r))
+}
+
+// RefTypeTable names ref types.
+type RefTypeTable map[RefType]string
+
+// DefaultRefTypes returns a copy of the bundled ref type names.
+func DefaultRefTypes() RefTypeTable {
+	t := make(RefTypeTable, len(refTypeNames))
+	for r, name := range refTypeNames {
+		t[r] = name
+	}
+	return t
+}
+
+// Table returns the ref type names of the response, falling back to the bundled
+// names for ref types it does not list.
+func (x *RefTypeXML) Table() RefTypeTable {
+	t := DefaultRefTypes()
+	for _, r := range x.RefTypes {
+		t[RefType(r.RefTypeID)] = r.RefTypeName
+	}
+	return t
+}
+
+// Name returns the name of a ref type.
+func (t RefTypeTable) Name(r RefType) string {
+	if name, ok := t[r]; ok {
+		return name
+	}
+	return r.String()
+}
@@ -0,0 +1,128 @@
+package eveapi
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// RouteMode selects how the route planner weighs jumps, as in the autopilot settings.
+type RouteMode int
+
+const (
+	RouteShortest RouteMode = iota
+	RouteSafest
+	RouteLessSecure
+)
+
+// HighSecurity is the lowest true security status shown as 0.5 or more in game.
+const HighSecurity = 0.45
+
+// Jumps outside the preferred security band cost this many jumps, so a route only
+// leaves the band when there is no other way.
+const routeSecurityPenalty = 50000
+
+var ErrNoRoute = errors.New("no route between solar systems")
+
+// RouteOptions controls the route planner. Avoided systems and regions are never
+// entered, except the destination itself.
+type RouteOptions struct {
+	Mode         RouteMode
+	AvoidSystems []int64
+	AvoidRegions []int64
+}
+
+// Route returns the solar systems to jump through from one system to another,
+// ending with the destination. The length of the route is the number of jumps and it
+// can be passed directly to SetRouteV1.
+func (g *JumpGraph) Route(from int64, to int64, opts RouteOptions) ([]int64, error) {
+	if g.systems[from] == nil || g.systems[to] == nil {
+		return nil, ErrNoRoute
+	}
+	if from == to {
+		return []int64{}, nil
+	}
+
+	avoidSystems := make(map[int64]bool)
+	for _, id := range opts.AvoidSystems {
+		avoidSystems[id] = true
+	}
+	avoidRegions := make(map[int64]bool)
+	for _, id := range opts.AvoidRegions {
+		avoidRegions[id] = true
+	}
+
+	cost := map[int64]int{from: 0}
+	previous := make(map[int64]int64)
+	queue := &routeQueue{{from, 0}}
+	for queue.Len() > 0 {
+		n := heap.Pop(queue).(routeNode)
+		if n.system == to {
+			break
+		}
+		if n.cost > cost[n.system] {
+			continue
+		}
+		for _, id := range g.systems[n.system].Gates {
+			s := g.systems[id]
+			if s == nil {
+				continue
+			}
+			if id != to && (avoidSystems[id] || avoidRegions[s.RegionID]) {
+				continue
+			}
+			c := n.cost + routeJumpCost(opts.Mode, s.Security)
+			if old, ok := cost[id]; ok && old <= c {
+				continue
+			}
+			cost[id] = c
+			previous[id] = n.system
+			heap.Push(queue, routeNode{id, c})
+		}
+	}
+
+	if _, ok := cost[to]; !ok {
+		return nil, ErrNoRoute
+	}
+	var route []int64
+	for id := to; id != from; id = previous[id] {
+		route = append(route, id)
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route, nil
+}
+
+// Cost of jumping into a system of the given security.
+func routeJumpCost(mode RouteMode, security float64) int {
+	switch mode {
+	case RouteSafest:
+		if security < HighSecurity {
+			return routeSecurityPenalty
+		}
+	case RouteLessSecure:
+		if security >= HighSecurity {
+			return routeSecurityPenalty
+		}
+	}
+	return 1
+}
+
+type routeNode struct {
+	system int64
+	cost   int
+}
+
+// routeQueue is a min-heap of systems by route cost.
+type routeQueue []routeNode
+
+func (q routeQueue) Len() int            { return len(q) }
+func (q routeQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q routeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *routeQueue) Push(x interface{}) { *q = append(*q, x.(routeNode)) }
+func (q *routeQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,46 @@
+package eveapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoute(t *testing.T) {
+	// A low-sec shortcut 1-2-3 and a high-sec detour 1-4-5-3 through region 20.
+	g := NewJumpGraph()
+	g.AddSolarSystem(1, 10, 0.9)
+	g.AddSolarSystem(2, 10, 0.4)
+	g.AddSolarSystem(3, 10, 0.5)
+	g.AddSolarSystem(4, 20, 0.7)
+	g.AddSolarSystem(5, 20, 0.6)
+	g.AddJump(1, 2)
+	g.AddJump(2, 3)
+	g.AddJump(1, 4)
+	g.AddJump(4, 5)
+	g.AddJump(5, 3)
+
+	for _, test := range []struct {
+		opts  RouteOptions
+		route []int64
+	}{
+		{RouteOptions{Mode: RouteShortest}, []int64{2, 3}},
+		{RouteOptions{Mode: RouteSafest}, []int64{4, 5, 3}},
+		{RouteOptions{Mode: RouteLessSecure}, []int64{2, 3}},
+		{RouteOptions{Mode: RouteShortest, AvoidSystems: []int64{2}}, []int64{4, 5, 3}},
+		{RouteOptions{Mode: RouteSafest, AvoidRegions: []int64{20}}, []int64{2, 3}},
+	} {
+		route, err := g.Route(1, 3, test.opts)
+		if err != nil {
+			t.Errorf("Error routing with %+v: %v", test.opts, err)
+			continue
+		}
+		if !reflect.DeepEqual(route, test.route) {
+			t.Errorf("Expected route %v with %+v, got %v", test.route, test.opts, route)
+		}
+	}
+
+	_, err := g.Route(1, 3, RouteOptions{AvoidSystems: []int64{2}, AvoidRegions: []int64{20}})
+	if err != ErrNoRoute {
+		t.Errorf("Expected no route, got %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
+
+// CostMethod is how a TradingLedger prices the units it sells.
+type CostMethod int
+
+const (
+	// CostFIFO sells the oldest units bought first.
+	CostFIFO CostMethod = iota
+	// CostAverage sells at the average price of the units held.
+	CostAverage
+)
+
+// Layout of the keys of TradingLedger.ByDay.
+const tradingDayLayout = "2006-01-02"
+
+// TradingLot is a quantity of a type bought at one price.
+type TradingLot struct {
+	Quantity int64
+	Price    float64
+	Date     time.Time
+}
+
+// TradingPL is the realized profit and loss of a type or a day.
+type TradingPL struct {
+	// Sold is the number of units sold.
+	Sold    int64
+	Revenue float64
+	Cost    float64
+	// Fees are brokers fees and transaction taxes. The journal only dates them, so
+	// they are recorded by day and shared between types by TradingLedger.Type.
+	Fees float64
+}
+
+// Profit returns the revenue less the cost and fees.
+func (p TradingPL) Profit() float64 {
+	return p.Revenue - p.Cost - p.Fees
+}
+
+func (p *TradingPL) add(o *TradingPL) {
+	p.Sold += o.Sold
+	p.Revenue += o.Revenue
+	p.Cost += o.Cost
+	p.Fees += o.Fees
+}
+
+// TradingLedger computes realized profit from wallet transactions and the open
+// inventory left to sell. It is updated incrementally: rows are remembered by ID and
+// skipped if added again, so overlapping pages and the divisions of a corporation
+// may be added in any order. The ledger is saved with Write and restored with
+// LoadTradingLedger.
+type TradingLedger struct {
+	Method CostMethod
+	// Corporation counts transactions made for the corporation rather than the
+	// personal ones of a character wallet.
+	Corporation bool
+
+	// Lots is the open inventory by typeID. Average cost keeps a single lot.
+	Lots map[int64][]TradingLot
+	// ByType and ByDay are the realized profit and loss by typeID and by day.
+	// ByType is before fees; use Type for the profit of a type after them.
+	ByType map[int64]*TradingPL
+	ByDay  map[string]*TradingPL
+	// DayRevenue is the revenue by day and typeID that fees are shared by.
+	DayRevenue map[string]map[int64]float64
+	// Unmatched counts units sold without a recorded buy by typeID. They are
+	// costed at zero.
+	Unmatched map[int64]int64
+
+	// Transactions and Refs are the transactionIDs and refIDs added.
+	Transactions map[int64]bool
+	Refs         map[int64]bool
+	// LastTransactionID and LastRefID are the highest IDs added.
+	LastTransactionID int64
+	LastRefID         int64
+}
+
+// NewTradingLedger returns an empty ledger costing sales with method.
+func NewTradingLedger(method CostMethod) *TradingLedger {
+	return &TradingLedger{
+		Method:       method,
+		Lots:         make(map[int64][]TradingLot),
+		ByType:       make(map[int64]*TradingPL),
+		ByDay:        make(map[string]*TradingPL),
+		DayRevenue:   make(map[string]map[int64]float64),
+		Unmatched:    make(map[int64]int64),
+		Transactions: make(map[int64]bool),
+		Refs:         make(map[int64]bool),
+	}
+}
+
+// LoadTradingLedger reads a ledger saved by Write.
+func LoadTradingLedger(r io.Reader) (*TradingLedger, error) {
+	l := NewTradingLedger(CostFIFO)
+	if err := json.NewDecoder(r).Decode(l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Write saves the ledger as JSON.
+func (l *TradingLedger) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(l)
+}
+
+// AddTransactions adds the buys and sells not added before, in date order. Sales
+// are costed from the lots held when they are added, so add older rows first.
+func (l *TradingLedger) AddTransactions(transactions []WalletTransactionEntryXML) {
+	rows := make([]WalletTransactionEntryXML, 0, len(transactions))
+	for _, t := range transactions {
+		if l.Transactions[t.TransactionID] {
+			continue
+		}
+		l.Transactions[t.TransactionID] = true
+		if t.TransactionID > l.LastTransactionID {
+			l.LastTransactionID = t.TransactionID
+		}
+		rows = append(rows, t)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		di, dj := rows[i].TransactionDateTime.Time, rows[j].TransactionDateTime.Time
+		if di.Equal(dj) {
+			return rows[i].TransactionID < rows[j].TransactionID
+		}
+		return di.Before(dj)
+	})
+
+	for _, t := range rows {
+		if !l.counts(t.TransactionFor) {
+			continue
+		}
+		switch t.TransactionType {
+		case "buy":
+			l.buy(t)
+		case "sell":
+			l.sell(t)
+		}
+	}
+}
+
+// AddJournal adds the brokers fees and transaction taxes of journal entries not
+// added before. Entries do not say who they were for, so pass the journal of the
+// wallet the ledger counts: the corporation's divisions when Corporation is set,
+// otherwise the character's own.
+func (l *TradingLedger) AddJournal(entries []WalletJournalEntryXML) {
+	for _, e := range entries {
+		if l.Refs[e.RefID] {
+			continue
+		}
+		l.Refs[e.RefID] = true
+		if e.RefID > l.LastRefID {
+			l.LastRefID = e.RefID
+		}
+		switch e.RefType() {
+		case RefTypeBrokersFee, RefTypeTransactionTax:
+			l.day(e.Date.Time).Fees -= e.Amount
+		}
+	}
+}
+
+// Open returns the quantity of a type held and the cost of it.
+func (l *TradingLedger) Open(typeID int64) (int64, float64) {
+	var quantity int64
+	var cost float64
+	for _, lot := range l.Lots[typeID] {
+		quantity += lot.Quantity
+		cost += float64(lot.Quantity) * lot.Price
+	}
+	return quantity, cost
+}
+
+// Type returns the realized profit and loss of a type, with the fees of each day
+// shared between the types sold that day by revenue. Fees of days without sales
+// are only in the day and total.
+func (l *TradingLedger) Type(typeID int64) TradingPL {
+	p := TradingPL{}
+	if byType, ok := l.ByType[typeID]; ok {
+		p = *byType
+	}
+	for d, revenue := range l.DayRevenue {
+		if day, ok := l.ByDay[d]; ok && day.Revenue > 0 {
+			p.Fees += day.Fees * revenue[typeID] / day.Revenue
+		}
+	}
+	return p
+}
+
+// Day returns the realized profit and loss of the UTC day of t.
+func (l *TradingLedger) Day(t time.Time) TradingPL {
+	if p, ok := l.ByDay[t.UTC().Format(tradingDayLayout)]; ok {
+		return *p
+	}
+	return TradingPL{}
+}
+
+// Days returns the days with activity, oldest first.
+func (l *TradingLedger) Days() []time.Time {
+	var days []time.Time
+	for d := range l.ByDay {
+		if t, err := time.Parse(tradingDayLayout, d); err == nil {
+			days = append(days, t)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+// Total returns the realized profit and loss of all days.
+func (l *TradingLedger) Total() TradingPL {
+	total := TradingPL{}
+	for _, p := range l.ByDay {
+		total.add(p)
+	}
+	return total
+}
+
+func (l *TradingLedger) counts(transactionFor string) bool {
+	switch transactionFor {
+	case "personal":
+		return !l.Corporation
+	case "corporation":
+		return l.Corporation
+	}
+	return true
+}
+
+func (l *TradingLedger) day(t time.Time) *TradingPL {
+	d := t.UTC().Format(tradingDayLayout)
+	p, ok := l.ByDay[d]
+	if !ok {
+		p = &TradingPL{}
+		l.ByDay[d] = p
+	}
+	return p
+}
+
+func (l *TradingLedger) buy(t WalletTransactionEntryXML) {
+	lot := TradingLot{t.Quantity, t.Price, t.TransactionDateTime.Time}
+	lots := l.Lots[t.TypeID]
+	if l.Method == CostAverage && len(lots) > 0 {
+		held := lots[0]
+		quantity := held.Quantity + lot.Quantity
+		lot.Price = (float64(held.Quantity)*held.Price + float64(lot.Quantity)*lot.Price) / float64(quantity)
+		lot.Quantity = quantity
+		lots = lots[:0]
+	}
+	l.Lots[t.TypeID] = append(lots, lot)
+}
+
+func (l *TradingLedger) sell(t WalletTransactionEntryXML) {
+	remaining := t.Quantity
+	var cost float64
+
+	lots := l.Lots[t.TypeID]
+	for remaining > 0 && len(lots) > 0 {
+		n := remaining
+		if lots[0].Quantity < n {
+			n = lots[0].Quantity
+		}
+		cost += float64(n) * lots[0].Price
+		remaining -= n
+		lots[0].Quantity -= n
+		if lots[0].Quantity == 0 {
+			lots = lots[1:]
+		}
+	}
+	if len(lots) == 0 {
+		delete(l.Lots, t.TypeID)
+	} else {
+		l.Lots[t.TypeID] = lots
+	}
+	if remaining > 0 {
+		l.Unmatched[t.TypeID] += remaining
+	}
+
+	p := &TradingPL{Sold: t.Quantity, Revenue: float64(t.Quantity) * t.Price, Cost: cost}
+	l.day(t.TransactionDateTime.Time).add(p)
+	d := t.TransactionDateTime.UTC().Format(tradingDayLayout)
+	revenue, ok := l.DayRevenue[d]
+	if !ok {
+		revenue = make(map[int64]float64)
+		l.DayRevenue[d] = revenue
+	}
+	revenue[t.TypeID] += p.Revenue
+	byType, ok := l.ByType[t.TypeID]
+	if !ok {
+		byType = &TradingPL{}
+		l.ByType[t.TypeID] = byType
+	}
+	byType.add(p)
+}
@@ -0,0 +1,119 @@
+package eveapi
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTradingLedger(t *testing.T) {
+	day1 := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	tx := func(id int64, date time.Time, kind string, quantity int64, price float64) WalletTransactionEntryXML {
+		return WalletTransactionEntryXML{
+			TransactionID:       id,
+			TransactionDateTime: EVEXMLTime{date},
+			TransactionType:     kind,
+			TransactionFor:      "personal",
+			TypeID:              34,
+			Quantity:            quantity,
+			Price:               price,
+		}
+	}
+	transactions := []WalletTransactionEntryXML{
+		tx(3, day2, "sell", 15, 10),
+		tx(1, day1, "buy", 10, 4),
+		tx(2, day1.Add(time.Hour), "buy", 10, 6),
+	}
+
+	fifo := NewTradingLedger(CostFIFO)
+	fifo.AddTransactions(transactions)
+	if p := fifo.ByType[34]; p.Sold != 15 || p.Revenue != 150 || p.Cost != 70 {
+		t.Errorf("Wrong FIFO P&L %+v", p)
+	}
+	if q, cost := fifo.Open(34); q != 5 || cost != 30 {
+		t.Errorf("Wrong FIFO open inventory %d %f", q, cost)
+	}
+
+	average := NewTradingLedger(CostAverage)
+	average.AddTransactions(transactions)
+	if p := average.ByType[34]; p.Cost != 75 {
+		t.Errorf("Wrong average cost P&L %+v", p)
+	}
+
+	fifo.AddJournal([]WalletJournalEntryXML{
+		{RefID: 10, RefTypeID: int64(RefTypeBrokersFee), Amount: -2, Date: EVEXMLTime{day1}},
+		{RefID: 11, RefTypeID: int64(RefTypeTransactionTax), Amount: -3, Date: EVEXMLTime{day2}},
+		{RefID: 12, RefTypeID: int64(RefTypePlayerDonation), Amount: 100, Date: EVEXMLTime{day2}},
+	})
+	if p := fifo.Day(day2); p.Fees != 3 || p.Profit() != 77 {
+		t.Errorf("Wrong day P&L %+v", p)
+	}
+	if p := fifo.Total(); p.Profit() != 75 {
+		t.Errorf("Wrong total P&L %+v", p)
+	}
+
+	// Saved state picks up where it left off and ignores rows already seen.
+	buf := &bytes.Buffer{}
+	if err := fifo.Write(buf); err != nil {
+		t.Fatalf("Error writing ledger %v", err)
+	}
+	loaded, err := LoadTradingLedger(buf)
+	if err != nil {
+		t.Fatalf("Error loading ledger %v", err)
+	}
+	loaded.AddTransactions(append(transactions, tx(4, day2, "sell", 10, 8)))
+	loaded.AddJournal([]WalletJournalEntryXML{{RefID: 11, RefTypeID: int64(RefTypeTransactionTax), Amount: -3, Date: EVEXMLTime{day2}}})
+	if p := loaded.ByType[34]; p.Sold != 25 || p.Cost != 100 || loaded.Unmatched[34] != 5 {
+		t.Errorf("Wrong P&L after update %+v unmatched %d", p, loaded.Unmatched[34])
+	}
+	if p := loaded.Day(day2); p.Fees != 3 {
+		t.Errorf("Journal entry counted twice %+v", p)
+	}
+	if days := loaded.Days(); len(days) != 2 || !days[0].Equal(time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Wrong days %v", days)
+	}
+}
+
+func TestTradingLedgerDivisions(t *testing.T) {
+	day := time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+	tx := func(id int64, typeID int64, kind string, quantity int64, price float64) WalletTransactionEntryXML {
+		return WalletTransactionEntryXML{
+			TransactionID:       id,
+			TransactionDateTime: EVEXMLTime{day.Add(time.Duration(id) * time.Minute)},
+			TransactionType:     kind,
+			TransactionFor:      "corporation",
+			TypeID:              typeID,
+			Quantity:            quantity,
+			Price:               price,
+		}
+	}
+
+	l := NewTradingLedger(CostFIFO)
+	l.Corporation = true
+	// Division 1000 then division 1001, whose IDs are lower.
+	l.AddTransactions([]WalletTransactionEntryXML{tx(100, 34, "buy", 10, 1), tx(101, 34, "sell", 10, 3)})
+	l.AddTransactions([]WalletTransactionEntryXML{tx(50, 35, "buy", 10, 2), tx(51, 35, "sell", 10, 8), tx(51, 35, "sell", 10, 8)})
+	if p := l.ByType[35]; p == nil || p.Sold != 10 || p.Revenue != 80 || p.Cost != 20 {
+		t.Errorf("Wrong P&L of the second division %+v", p)
+	}
+	if l.LastTransactionID != 101 {
+		t.Errorf("Wrong last transactionID %d", l.LastTransactionID)
+	}
+
+	l.AddJournal([]WalletJournalEntryXML{{RefID: 20, RefTypeID: int64(RefTypeBrokersFee), Amount: -6, Date: EVEXMLTime{day}}})
+	l.AddJournal([]WalletJournalEntryXML{{RefID: 10, RefTypeID: int64(RefTypeTransactionTax), Amount: -4, Date: EVEXMLTime{day}}})
+	if p := l.Day(day); p.Fees != 10 {
+		t.Errorf("Wrong day fees %+v", p)
+	}
+
+	// Fees are shared by revenue: 30 of type 34 and 80 of type 35.
+	p34, p35 := l.Type(34), l.Type(35)
+	if math.Abs(p34.Fees-10*30.0/110) > 1e-9 || math.Abs(p35.Fees-10*80.0/110) > 1e-9 {
+		t.Errorf("Wrong fees by type %f %f", p34.Fees, p35.Fees)
+	}
+	if math.Abs(p34.Profit()+p35.Profit()-l.Total().Profit()) > 1e-9 {
+		t.Errorf("Type profits %f %f do not add up to %f", p34.Profit(), p35.Profit(), l.Total().Profit())
+	}
+}
@@ -0,0 +1,123 @@
+package eveapi
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	postWaypointV1Type      = "application/vnd.ccp.eve.PostWaypoint-v1"
+	showContractV1Type      = "application/vnd.ccp.eve.ShowContract-v1"
+	showOwnerDetailsV1Type  = "application/vnd.ccp.eve.ShowOwnerDetails-v1"
+	showMarketDetailsV1Type = "application/vnd.ccp.eve.ShowMarketDetails-v1"
+	showNewMailV1Type       = "application/vnd.ccp.eve.ShowNewMailMessage-v1"
+)
+
+type postWaypoint struct {
+	ClearOtherWaypoints bool   `json:"clearOtherWaypoints"`
+	First               bool   `json:"first"`
+	SolarSystem         idHref `json:"solarSystem"`
+}
+
+// SetWaypointV1 adds a solar system to the character's autopilot route.
+// clearOtherWaypoints replaces the current route and first inserts the waypoint
+// at the start of the route. Requires ScopeCharacterNavigationWrite.
+func (c *CharacterV4) SetWaypointV1(auth oauth2.TokenSource, solarSystemID int64, clearOtherWaypoints bool, first bool) error {
+	p := postWaypoint{
+		ClearOtherWaypoints: clearOtherWaypoints,
+		First:               first,
+		SolarSystem: idHref{
+			ID:   solarSystemID,
+			Href: c.base.CREST + fmt.Sprintf("solarsystems/%d/", solarSystemID),
+		},
+	}
+	_, err := c.doJSON("POST", c.href(c.UI.SetWaypoints, "ui/autopilot/waypoints/"), p, nil, postWaypointV1Type, auth)
+	return err
+}
+
+// SetDestinationV1 replaces the character's autopilot route with a single destination.
+// Requires ScopeCharacterNavigationWrite.
+func (c *CharacterV4) SetDestinationV1(auth oauth2.TokenSource, solarSystemID int64) error {
+	return c.SetWaypointV1(auth, solarSystemID, true, false)
+}
+
+// SetRouteV1 replaces the character's autopilot route with the solar systems in order.
+// Requires ScopeCharacterNavigationWrite.
+func (c *CharacterV4) SetRouteV1(auth oauth2.TokenSource, solarSystemIDs []int64) error {
+	if len(solarSystemIDs) == 0 {
+		return errors.New("route has no solar systems")
+	}
+	for i, id := range solarSystemIDs {
+		if err := c.SetWaypointV1(auth, id, i == 0, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShowContractV1 opens a contract window in the character's client.
+// Requires ScopeRemoteClientUI.
+func (c *CharacterV4) ShowContractV1(auth oauth2.TokenSource, contractID int64) error {
+	p := struct {
+		Contract idHref `json:"contract"`
+	}{idHref{
+		ID:   contractID,
+		Href: c.base.CREST + fmt.Sprintf("contracts/%d/", contractID),
+	}}
+	_, err := c.doJSON("POST", c.href(c.UI.ShowContract, "ui/openwindow/contract/"), p, nil, showContractV1Type, auth)
+	return err
+}
+
+// ShowOwnerDetailsV1 opens the show info window of a character, corporation or alliance
+// in the character's client. Requires ScopeRemoteClientUI.
+func (c *CharacterV4) ShowOwnerDetailsV1(auth oauth2.TokenSource, ownerID int64) error {
+	p := struct {
+		ID int64 `json:"id"`
+	}{ownerID}
+	_, err := c.doJSON("POST", c.href(c.UI.ShowOwnerDetails, "ui/openwindow/ownerdetails/"), p, nil, showOwnerDetailsV1Type, auth)
+	return err
+}
+
+// ShowMarketDetailsV1 opens the market window for a type in the character's client.
+// Requires ScopeRemoteClientUI.
+func (c *CharacterV4) ShowMarketDetailsV1(auth oauth2.TokenSource, typeID int64) error {
+	p := struct {
+		Type idHref `json:"type"`
+	}{idHref{
+		ID:   typeID,
+		Href: c.base.CREST + fmt.Sprintf("inventory/types/%d/", typeID),
+	}}
+	_, err := c.doJSON("POST", c.href(c.UI.ShowMarketDetails, "ui/openwindow/marketdetails/"), p, nil, showMarketDetailsV1Type, auth)
+	return err
+}
+
+// NewMail is a mail to pre-fill in a new mail window.
+type NewMail struct {
+	Recipients []int64 // characterIDs
+	Subject    string
+	Body       string
+}
+
+// ShowNewMailWindowV1 opens a new mail window in the character's client, pre-filled with
+// the recipients, subject and body. Requires ScopeRemoteClientUI.
+func (c *CharacterV4) ShowNewMailWindowV1(auth oauth2.TokenSource, mail *NewMail) error {
+	p := struct {
+		Recipients []idHref `json:"recipients"`
+		Subject    string   `json:"subject"`
+		Body       string   `json:"body"`
+	}{
+		Recipients: make([]idHref, 0, len(mail.Recipients)),
+		Subject:    mail.Subject,
+		Body:       mail.Body,
+	}
+	for _, id := range mail.Recipients {
+		p.Recipients = append(p.Recipients, idHref{
+			ID:   id,
+			Href: c.base.CREST + fmt.Sprintf("characters/%d/", id),
+		})
+	}
+	_, err := c.doJSON("POST", c.href(c.UI.ShowNewMailWindow, "ui/openwindow/newmail/"), p, nil, showNewMailV1Type, auth)
+	return err
+}
@@ -0,0 +1,90 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+type testUIRequest struct {
+	method, path, contentType, auth string
+	body                            map[string]interface{}
+}
+
+func TestUIWaypoints(t *testing.T) {
+	var requests []testUIRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := ioutil.ReadAll(r.Body)
+		req := testUIRequest{
+			method:      r.Method,
+			path:        r.URL.Path,
+			contentType: r.Header.Get("Content-Type"),
+			auth:        r.Header.Get("Authorization"),
+		}
+		if err := json.Unmarshal(buf, &req.body); err != nil {
+			t.Errorf("Bad request body %q", buf)
+		}
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	char := &CharacterV4{EVEAPIClient: c}
+	char.ID = 90000001
+	auth := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+
+	if err := char.SetDestinationV1(auth, 30000142); err != nil {
+		t.Fatalf("Error setting destination %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	if req.method != "POST" || req.path != "/characters/90000001/ui/autopilot/waypoints/" {
+		t.Errorf("Wrong request %s %s", req.method, req.path)
+	}
+	if req.contentType != postWaypointV1Type || req.auth != "Bearer token" {
+		t.Errorf("Wrong headers %q %q", req.contentType, req.auth)
+	}
+	system := req.body["solarSystem"].(map[string]interface{})
+	if req.body["clearOtherWaypoints"] != true || req.body["first"] != false ||
+		system["id"] != float64(30000142) || system["href"] != srv.URL+"/solarsystems/30000142/" {
+		t.Errorf("Wrong destination body %v", req.body)
+	}
+
+	// A route clears the old route with its first system only.
+	requests = nil
+	if err := char.SetRouteV1(auth, []int64{30000142, 30000144, 30002187}); err != nil {
+		t.Fatalf("Error setting route %v", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	for i, id := range []float64{30000142, 30000144, 30002187} {
+		body := requests[i].body
+		if body["solarSystem"].(map[string]interface{})["id"] != id || body["clearOtherWaypoints"] != (i == 0) || body["first"] != false {
+			t.Errorf("Wrong waypoint %d body %v", i, body)
+		}
+	}
+	if err := char.SetRouteV1(auth, nil); err == nil {
+		t.Errorf("Expected an error for an empty route")
+	}
+
+	// Links from the character are used when present.
+	requests = nil
+	char.UI.ShowNewMailWindow.Href = srv.URL + "/linked/newmail/"
+	if err := char.ShowNewMailWindowV1(auth, &NewMail{Recipients: []int64{1, 2}, Subject: "Hi", Body: "Hello"}); err != nil {
+		t.Fatalf("Error opening mail window %v", err)
+	}
+	req = requests[0]
+	if req.path != "/linked/newmail/" || req.contentType != showNewMailV1Type {
+		t.Errorf("Wrong mail request %s %s", req.path, req.contentType)
+	}
+	if recipients := req.body["recipients"].([]interface{}); len(recipients) != 2 || req.body["subject"] != "Hi" {
+		t.Errorf("Wrong mail body %v", req.body)
+	}
+}
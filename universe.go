@@ -0,0 +1,134 @@
+package eveapi
+
+import "fmt"
+
+const (
+	regionsCollectionV1Type = "application/vnd.ccp.eve.RegionCollection-v1"
+	regionV1Type            = "application/vnd.ccp.eve.Region-v1"
+	constellationV1Type     = "application/vnd.ccp.eve.Constellation-v1"
+	solarSystemV1Type       = "application/vnd.ccp.eve.SolarSystem-v1"
+	stargateV1Type          = "application/vnd.ccp.eve.Stargate-v1"
+)
+
+// Position of a celestial in meters.
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+type RegionsCollectionV1 struct {
+	*EVEAPIClient
+	crestPagedFrame
+	Items []namedReference
+}
+
+func (c *EVEAPIClient) RegionsV1() (*RegionsCollectionV1, error) {
+	w := &RegionsCollectionV1{EVEAPIClient: c}
+	url := c.base.CREST + "regions/"
+	res, err := c.doJSON("GET", url, nil, w, regionsCollectionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(url, res)
+	return w, nil
+}
+
+type RegionV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	Name           string
+	Description    string
+	Constellations []idHref
+}
+
+func (c *EVEAPIClient) RegionV1(href string) (*RegionV1, error) {
+	w := &RegionV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, regionV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) RegionV1ByID(regionID int64) (*RegionV1, error) {
+	return c.RegionV1(c.base.CREST + fmt.Sprintf("regions/%d/", regionID))
+}
+
+type ConstellationV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	Name     string
+	Position Position
+	Region   simpleHref
+	Systems  []idHref
+}
+
+func (c *EVEAPIClient) ConstellationV1(href string) (*ConstellationV1, error) {
+	w := &ConstellationV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, constellationV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) ConstellationV1ByID(constellationID int64) (*ConstellationV1, error) {
+	return c.ConstellationV1(c.base.CREST + fmt.Sprintf("constellations/%d/", constellationID))
+}
+
+type SolarSystemV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	ID             int64
+	Name           string
+	SecurityStatus float64
+	SecurityClass  string
+	Position       Position
+	Constellation  idHref
+	Stargates      []namedReference
+	Stations       []namedReference
+	Planets        []simpleHref
+}
+
+func (c *EVEAPIClient) SolarSystemV1(href string) (*SolarSystemV1, error) {
+	w := &SolarSystemV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, solarSystemV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) SolarSystemV1ByID(solarSystemID int64) (*SolarSystemV1, error) {
+	return c.SolarSystemV1(c.base.CREST + fmt.Sprintf("solarsystems/%d/", solarSystemID))
+}
+
+type StargateV1 struct {
+	*EVEAPIClient
+	crestSimpleFrame
+	Name        string
+	Position    Position
+	System      namedReference
+	Destination struct {
+		Stargate namedReference
+		System   namedReference
+	}
+}
+
+func (c *EVEAPIClient) StargateV1(href string) (*StargateV1, error) {
+	w := &StargateV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, stargateV1Type, nil)
+	if err != nil {
+		return nil, err
+	}
+	w.getFrameInfo(res)
+	return w, nil
+}
+
+func (c *EVEAPIClient) StargateV1ByID(stargateID int64) (*StargateV1, error) {
+	return c.StargateV1(c.base.CREST + fmt.Sprintf("stargates/%d/", stargateID))
+}
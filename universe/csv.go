@@ -0,0 +1,193 @@
+package universe
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/antihax/eveapi/internal/sdecsv"
+)
+
+// Static data export tables, named as in the Fuzzwork CSV conversion.
+const (
+	RegionsFile        = "mapRegions.csv"
+	ConstellationsFile = "mapConstellations.csv"
+	SolarSystemsFile   = "mapSolarSystems.csv"
+	StationsFile       = "staStations.csv"
+	JumpsFile          = "mapSolarSystemJumps.csv"
+)
+
+var (
+	regionColumns        = []string{"regionID", "regionName", "x", "y", "z"}
+	constellationColumns = []string{"regionID", "constellationID", "constellationName", "x", "y", "z"}
+	solarSystemColumns   = []string{"regionID", "constellationID", "solarSystemID", "solarSystemName", "x", "y", "z", "security", "securityClass"}
+	stationColumns       = []string{"stationID", "stationTypeID", "corporationID", "solarSystemID", "constellationID", "regionID", "stationName", "x", "y", "z"}
+	jumpColumns          = []string{"fromSolarSystemID", "toSolarSystemID"}
+)
+
+// Load reads the universe tables from the CSV files in dir. Columns are found by
+// header name so full exports with extra columns load as well.
+func Load(dir string) (*Universe, error) {
+	u := New()
+	for _, t := range []struct {
+		file string
+		load func(io.Reader) error
+	}{
+		{RegionsFile, u.readRegions},
+		{ConstellationsFile, u.readConstellations},
+		{SolarSystemsFile, u.readSolarSystems},
+		{StationsFile, u.readStations},
+		{JumpsFile, u.readJumps},
+	} {
+		if err := sdecsv.LoadFile(filepath.Join(dir, t.file), t.load); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// Save writes the universe tables as CSV files in dir, readable by Load.
+func (u *Universe) Save(dir string) error {
+	for _, t := range []struct {
+		file string
+		save func(io.Writer) error
+	}{
+		{RegionsFile, u.writeRegions},
+		{ConstellationsFile, u.writeConstellations},
+		{SolarSystemsFile, u.writeSolarSystems},
+		{StationsFile, u.writeStations},
+		{JumpsFile, u.writeJumps},
+	} {
+		if err := sdecsv.SaveFile(filepath.Join(dir, t.file), t.save); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Universe) readRegions(in io.Reader) error {
+	return sdecsv.Read(in, []string{"regionID", "regionName"}, func(r *sdecsv.Row) {
+		u.AddRegion(Region{
+			ID:   r.Int("regionID"),
+			Name: r.String("regionName"),
+			X:    r.Float("x"),
+			Y:    r.Float("y"),
+			Z:    r.Float("z"),
+		})
+	})
+}
+
+func (u *Universe) readConstellations(in io.Reader) error {
+	return sdecsv.Read(in, []string{"regionID", "constellationID", "constellationName"}, func(r *sdecsv.Row) {
+		u.AddConstellation(Constellation{
+			ID:       r.Int("constellationID"),
+			RegionID: r.Int("regionID"),
+			Name:     r.String("constellationName"),
+			X:        r.Float("x"),
+			Y:        r.Float("y"),
+			Z:        r.Float("z"),
+		})
+	})
+}
+
+func (u *Universe) readSolarSystems(in io.Reader) error {
+	return sdecsv.Read(in, []string{"regionID", "constellationID", "solarSystemID", "solarSystemName", "security"}, func(r *sdecsv.Row) {
+		u.AddSolarSystem(SolarSystem{
+			ID:              r.Int("solarSystemID"),
+			ConstellationID: r.Int("constellationID"),
+			RegionID:        r.Int("regionID"),
+			Name:            r.String("solarSystemName"),
+			Security:        r.Float("security"),
+			SecurityClass:   r.String("securityClass"),
+			X:               r.Float("x"),
+			Y:               r.Float("y"),
+			Z:               r.Float("z"),
+		})
+	})
+}
+
+func (u *Universe) readStations(in io.Reader) error {
+	return sdecsv.Read(in, []string{"stationID", "solarSystemID", "stationName"}, func(r *sdecsv.Row) {
+		u.AddStation(Station{
+			ID:              r.Int("stationID"),
+			TypeID:          r.Int("stationTypeID"),
+			CorporationID:   r.Int("corporationID"),
+			SolarSystemID:   r.Int("solarSystemID"),
+			ConstellationID: r.Int("constellationID"),
+			RegionID:        r.Int("regionID"),
+			Name:            r.String("stationName"),
+			X:               r.Float("x"),
+			Y:               r.Float("y"),
+			Z:               r.Float("z"),
+		})
+	})
+}
+
+func (u *Universe) readJumps(in io.Reader) error {
+	return sdecsv.Read(in, jumpColumns, func(r *sdecsv.Row) {
+		u.AddJump(r.Int("fromSolarSystemID"), r.Int("toSolarSystemID"))
+	})
+}
+
+func (u *Universe) writeRegions(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(u.Regions))
+	for id := range u.Regions {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		r := u.Regions[id]
+		records = append(records, []string{sdecsv.FormatInt(r.ID), r.Name, sdecsv.FormatFloat(r.X), sdecsv.FormatFloat(r.Y), sdecsv.FormatFloat(r.Z)})
+	}
+	return sdecsv.Write(out, regionColumns, records)
+}
+
+func (u *Universe) writeConstellations(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(u.Constellations))
+	for id := range u.Constellations {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		c := u.Constellations[id]
+		records = append(records, []string{sdecsv.FormatInt(c.RegionID), sdecsv.FormatInt(c.ID), c.Name,
+			sdecsv.FormatFloat(c.X), sdecsv.FormatFloat(c.Y), sdecsv.FormatFloat(c.Z)})
+	}
+	return sdecsv.Write(out, constellationColumns, records)
+}
+
+func (u *Universe) writeSolarSystems(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(u.SolarSystems))
+	for id := range u.SolarSystems {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		s := u.SolarSystems[id]
+		records = append(records, []string{sdecsv.FormatInt(s.RegionID), sdecsv.FormatInt(s.ConstellationID), sdecsv.FormatInt(s.ID), s.Name,
+			sdecsv.FormatFloat(s.X), sdecsv.FormatFloat(s.Y), sdecsv.FormatFloat(s.Z), sdecsv.FormatFloat(s.Security), s.SecurityClass})
+	}
+	return sdecsv.Write(out, solarSystemColumns, records)
+}
+
+func (u *Universe) writeStations(out io.Writer) error {
+	var records [][]string
+	ids := make([]int64, 0, len(u.Stations))
+	for id := range u.Stations {
+		ids = append(ids, id)
+	}
+	for _, id := range sdecsv.SortIDs(ids) {
+		s := u.Stations[id]
+		records = append(records, []string{sdecsv.FormatInt(s.ID), sdecsv.FormatInt(s.TypeID), sdecsv.FormatInt(s.CorporationID),
+			sdecsv.FormatInt(s.SolarSystemID), sdecsv.FormatInt(s.ConstellationID), sdecsv.FormatInt(s.RegionID), s.Name,
+			sdecsv.FormatFloat(s.X), sdecsv.FormatFloat(s.Y), sdecsv.FormatFloat(s.Z)})
+	}
+	return sdecsv.Write(out, stationColumns, records)
+}
+
+func (u *Universe) writeJumps(out io.Writer) error {
+	var records [][]string
+	for _, j := range u.Jumps {
+		records = append(records, []string{sdecsv.FormatInt(j.FromSolarSystemID), sdecsv.FormatInt(j.ToSolarSystemID)})
+	}
+	return sdecsv.Write(out, jumpColumns, records)
+}
@@ -0,0 +1,148 @@
+package universe
+
+import (
+	"sync"
+
+	"github.com/antihax/eveapi"
+	"github.com/antihax/eveapi/internal/href"
+	"github.com/antihax/eveapi/internal/workers"
+)
+
+// Snapshot builds the universe from the CREST region, constellation, solar system
+// and stargate endpoints, adding outposts from the conquerable station list.
+// Each level is fetched by concurrency workers and the first error stops the snapshot.
+// Save the result to look universe data up offline.
+func Snapshot(c *eveapi.EVEAPIClient, concurrency int) (*Universe, error) {
+	u := New()
+	mu := sync.Mutex{}
+
+	regions, err := c.RegionsV1()
+	if err != nil {
+		return nil, err
+	}
+
+	// Regions list their constellations.
+	type constellationRef struct {
+		regionID int64
+		href     string
+	}
+	var constellations []constellationRef
+	err = workers.Run(concurrency, len(regions.Items), func(i int) error {
+		item := regions.Items[i]
+		r, err := c.RegionV1(item.Href)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		u.AddRegion(Region{ID: item.ID, Name: item.Name})
+		for _, con := range r.Constellations {
+			constellations = append(constellations, constellationRef{item.ID, con.Href})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Constellations list their solar systems.
+	type systemRef struct {
+		regionID        int64
+		constellationID int64
+		href            string
+	}
+	var systems []systemRef
+	err = workers.Run(concurrency, len(constellations), func(i int) error {
+		ref := constellations[i]
+		con, err := c.ConstellationV1(ref.href)
+		if err != nil {
+			return err
+		}
+		id := href.ID(ref.href)
+		mu.Lock()
+		defer mu.Unlock()
+		u.AddConstellation(Constellation{
+			ID:       id,
+			RegionID: ref.regionID,
+			Name:     con.Name,
+			X:        con.Position.X,
+			Y:        con.Position.Y,
+			Z:        con.Position.Z,
+		})
+		for _, s := range con.Systems {
+			systems = append(systems, systemRef{ref.regionID, id, s.Href})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Solar systems list their stations and stargates.
+	var stargates []string
+	err = workers.Run(concurrency, len(systems), func(i int) error {
+		ref := systems[i]
+		s, err := c.SolarSystemV1(ref.href)
+		if err != nil {
+			return err
+		}
+		id := s.ID
+		if id == 0 {
+			id = href.ID(ref.href)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		u.AddSolarSystem(SolarSystem{
+			ID:              id,
+			ConstellationID: ref.constellationID,
+			RegionID:        ref.regionID,
+			Name:            s.Name,
+			Security:        s.SecurityStatus,
+			SecurityClass:   s.SecurityClass,
+			X:               s.Position.X,
+			Y:               s.Position.Y,
+			Z:               s.Position.Z,
+		})
+		for _, st := range s.Stations {
+			u.AddStation(Station{ID: st.ID, SolarSystemID: id, Name: st.Name})
+		}
+		for _, g := range s.Stargates {
+			stargates = append(stargates, g.Href)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Stargates give the jumps.
+	err = workers.Run(concurrency, len(stargates), func(i int) error {
+		g, err := c.StargateV1(stargates[i])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		u.AddJump(g.System.ID, g.Destination.System.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outposts, err := c.ConquerableStationsListXML()
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range outposts.Stations {
+		u.AddStation(Station{
+			ID:            st.StationID,
+			TypeID:        st.StationTypeID,
+			CorporationID: st.CorporationID,
+			SolarSystemID: st.SolarSystemID,
+			Name:          st.StationName,
+		})
+	}
+
+	return u, nil
+}
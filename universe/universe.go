@@ -0,0 +1,175 @@
+// Package universe holds static universe data (regions, constellations, solar
+// systems, stations and stargate jumps) indexed in memory for offline lookups.
+//
+// Data is loaded from the CSV conversion of the static data export published by
+// Fuzzwork, or snapshotted from CREST and saved in the same format. The YAML and
+// SQLite forms of the export are not read as they need decoders outside the
+// standard library.
+package universe
+
+import (
+	"strings"
+
+	"github.com/antihax/eveapi"
+)
+
+type Region struct {
+	ID   int64
+	Name string
+	X    float64
+	Y    float64
+	Z    float64
+}
+
+type Constellation struct {
+	ID       int64
+	RegionID int64
+	Name     string
+	X        float64
+	Y        float64
+	Z        float64
+}
+
+type SolarSystem struct {
+	ID              int64
+	ConstellationID int64
+	RegionID        int64
+	Name            string
+	Security        float64
+	SecurityClass   string
+	X               float64
+	Y               float64
+	Z               float64
+}
+
+type Station struct {
+	ID              int64
+	TypeID          int64
+	CorporationID   int64
+	SolarSystemID   int64
+	ConstellationID int64
+	RegionID        int64
+	Name            string
+	X               float64
+	Y               float64
+	Z               float64
+}
+
+// Jump is a one way stargate connection between two solar systems.
+type Jump struct {
+	FromSolarSystemID int64
+	ToSolarSystemID   int64
+}
+
+// Universe is the static universe indexed by ID and by case-insensitive name.
+type Universe struct {
+	Regions        map[int64]*Region
+	Constellations map[int64]*Constellation
+	SolarSystems   map[int64]*SolarSystem
+	Stations       map[int64]*Station
+	Jumps          []Jump
+
+	regionNames        map[string]int64
+	constellationNames map[string]int64
+	solarSystemNames   map[string]int64
+	stationNames       map[string]int64
+	jumps              map[Jump]bool
+}
+
+func New() *Universe {
+	return &Universe{
+		Regions:            make(map[int64]*Region),
+		Constellations:     make(map[int64]*Constellation),
+		SolarSystems:       make(map[int64]*SolarSystem),
+		Stations:           make(map[int64]*Station),
+		regionNames:        make(map[string]int64),
+		constellationNames: make(map[string]int64),
+		solarSystemNames:   make(map[string]int64),
+		stationNames:       make(map[string]int64),
+		jumps:              make(map[Jump]bool),
+	}
+}
+
+func (u *Universe) AddRegion(r Region) {
+	u.Regions[r.ID] = &r
+	u.regionNames[strings.ToLower(r.Name)] = r.ID
+}
+
+func (u *Universe) AddConstellation(c Constellation) {
+	u.Constellations[c.ID] = &c
+	u.constellationNames[strings.ToLower(c.Name)] = c.ID
+}
+
+func (u *Universe) AddSolarSystem(s SolarSystem) {
+	u.SolarSystems[s.ID] = &s
+	u.solarSystemNames[strings.ToLower(s.Name)] = s.ID
+}
+
+// AddStation adds a station, filling its constellation and region from its solar
+// system when they are missing.
+func (u *Universe) AddStation(s Station) {
+	if sys, ok := u.SolarSystems[s.SolarSystemID]; ok {
+		if s.ConstellationID == 0 {
+			s.ConstellationID = sys.ConstellationID
+		}
+		if s.RegionID == 0 {
+			s.RegionID = sys.RegionID
+		}
+	}
+	u.Stations[s.ID] = &s
+	u.stationNames[strings.ToLower(s.Name)] = s.ID
+}
+
+// AddJump adds a one way stargate connection, ignoring duplicates.
+func (u *Universe) AddJump(fromSolarSystemID int64, toSolarSystemID int64) {
+	j := Jump{fromSolarSystemID, toSolarSystemID}
+	if u.jumps[j] {
+		return
+	}
+	u.jumps[j] = true
+	u.Jumps = append(u.Jumps, j)
+}
+
+func (u *Universe) RegionByName(name string) (*Region, bool) {
+	r, ok := u.Regions[u.regionNames[strings.ToLower(name)]]
+	return r, ok
+}
+
+func (u *Universe) ConstellationByName(name string) (*Constellation, bool) {
+	c, ok := u.Constellations[u.constellationNames[strings.ToLower(name)]]
+	return c, ok
+}
+
+func (u *Universe) SolarSystemByName(name string) (*SolarSystem, bool) {
+	s, ok := u.SolarSystems[u.solarSystemNames[strings.ToLower(name)]]
+	return s, ok
+}
+
+func (u *Universe) StationByName(name string) (*Station, bool) {
+	s, ok := u.Stations[u.stationNames[strings.ToLower(name)]]
+	return s, ok
+}
+
+// StationSolarSystemID returns the solar system of a station.
+func (u *Universe) StationSolarSystemID(stationID int64) (int64, bool) {
+	s, ok := u.Stations[stationID]
+	if !ok {
+		return 0, false
+	}
+	return s.SolarSystemID, true
+}
+
+// JumpGraph builds the stargate graph of the universe with its stations.
+func (u *Universe) JumpGraph() *eveapi.JumpGraph {
+	g := eveapi.NewJumpGraph()
+	for _, s := range u.SolarSystems {
+		g.AddSolarSystem(s.ID, s.RegionID, s.Security)
+	}
+	for _, j := range u.Jumps {
+		g.AddJump(j.FromSolarSystemID, j.ToSolarSystemID)
+	}
+	for _, s := range u.Stations {
+		g.AddStation(s.ID, s.SolarSystemID)
+	}
+	return g
+}
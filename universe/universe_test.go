@@ -0,0 +1,76 @@
+package universe
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Trimmed rows of the Fuzzwork conversion, with their extra columns.
+var testFiles = map[string]string{
+	RegionsFile: `regionID,regionName,x,y,z,xMin,xMax,yMin,yMax,zMin,zMax,factionID,radius
+10000002,"The Forge",-9.64e+16,6.4e+16,1.12e+17,0,0,0,0,0,0,500001,None
+`,
+	ConstellationsFile: `regionID,constellationID,constellationName,x,y,z,xMin,xMax,yMin,yMax,zMin,zMax,factionID,radius
+10000002,20000020,Kimotoro,-1.3e+17,6.0e+16,1.1e+17,0,0,0,0,0,0,None,None
+`,
+	SolarSystemsFile: `regionID,constellationID,solarSystemID,solarSystemName,x,y,z,xMin,xMax,yMin,yMax,zMin,zMax,luminosity,border,fringe,corridor,hub,international,regional,constellation,security,factionID,radius,sunTypeID,securityClass
+10000002,20000020,30000142,Jita,-1.29e+17,6.07e+16,1.17e+17,0,0,0,0,0,0,0.01,1,0,0,1,1,1,0,0.945913116664839,None,0,45041,B
+10000002,20000020,30000144,Perimeter,-1.29e+17,6.07e+16,1.17e+17,0,0,0,0,0,0,0.01,1,0,0,1,1,1,0,0.954734444618225,None,0,45041,B
+`,
+	StationsFile: `stationID,security,dockingCostPerVolume,maxShipVolumeDockable,officeRentalCost,operationID,stationTypeID,corporationID,solarSystemID,constellationID,regionID,stationName,x,y,z,reprocessingEfficiency,reprocessingStationsTake,reprocessingHangarFlag
+60003760,0.9459,0,50000000,10000,26,1531,1000035,30000142,20000020,10000002,"Jita IV - Moon 4 - Caldari Navy Assembly Plant",-1.07e+11,-1.87e+10,4.4e+11,0.5,0.05,4
+`,
+	JumpsFile: `fromRegionID,fromConstellationID,fromSolarSystemID,toSolarSystemID,toConstellationID,toRegionID
+10000002,20000020,30000142,30000144,20000020,10000002
+10000002,20000020,30000144,30000142,20000020,10000002
+`,
+}
+
+func TestUniverseLoadSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "universe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, data := range testFiles {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	u, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Error loading universe %v", err)
+	}
+
+	// Save and reload in our own format.
+	saved, err := ioutil.TempDir("", "universe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(saved)
+	if err := u.Save(saved); err != nil {
+		t.Fatalf("Error saving universe %v", err)
+	}
+	if u, err = Load(saved); err != nil {
+		t.Fatalf("Error reloading universe %v", err)
+	}
+
+	if r, ok := u.RegionByName("the forge"); !ok || r.ID != 10000002 {
+		t.Errorf("Region lookup failed %v", r)
+	}
+	s, ok := u.SolarSystemByName("JITA")
+	if !ok || s.ConstellationID != 20000020 || s.Security < 0.94 || s.SecurityClass != "B" {
+		t.Errorf("Solar system lookup failed %v", s)
+	}
+	if id, ok := u.StationSolarSystemID(60003760); !ok || id != 30000142 {
+		t.Errorf("Station in wrong solar system %d", id)
+	}
+
+	g := u.JumpGraph()
+	if jumps, ok := g.Jumps(30000142, 30000144); !ok || jumps != 1 {
+		t.Errorf("Expected one jump, got %d", jumps)
+	}
+}
@@ -0,0 +1,173 @@
+package eveapi
+
+import (
+	"sort"
+	"time"
+)
+
+// Rows requested per wallet page, the most the XML API returns.
+const walletRowCount = 2560
+
+// WalletWalkOptions limits how far back a wallet walk goes. The XML API only
+// returns about a month of history whatever the options.
+type WalletWalkOptions struct {
+	// StopID stops the walk at this refID or transactionID, which is not returned.
+	StopID int64
+	// Since stops the walk at rows older than this time.
+	Since time.Time
+}
+
+// walletRow is a journal entry or transaction.
+type walletRow interface {
+	rowID() int64
+	rowDate() time.Time
+}
+
+func (e WalletJournalEntryXML) rowID() int64           { return e.RefID }
+func (e WalletJournalEntryXML) rowDate() time.Time     { return e.Date.Time }
+func (e WalletTransactionEntryXML) rowID() int64       { return e.TransactionID }
+func (e WalletTransactionEntryXML) rowDate() time.Time { return e.TransactionDateTime.Time }
+
+// walkWallet fetches pages back from the newest row until the options or the end
+// of the history stop it. Rows repeated between pages are dropped and the rows are
+// returned oldest first.
+func walkWallet(fetch func(fromID int64) ([]walletRow, error), opts WalletWalkOptions) ([]walletRow, error) {
+	seen := make(map[int64]bool)
+	var rows []walletRow
+
+	var fromID int64
+	for {
+		page, err := fetch(fromID)
+		if err != nil {
+			return nil, err
+		}
+
+		oldest := fromID
+		stop := false
+		for _, r := range page {
+			id := r.rowID()
+			if oldest == 0 || id < oldest {
+				oldest = id
+			}
+			if seen[id] {
+				continue
+			}
+			if (opts.StopID > 0 && id <= opts.StopID) || (!opts.Since.IsZero() && r.rowDate().Before(opts.Since)) {
+				stop = true
+				continue
+			}
+			seen[id] = true
+			rows = append(rows, r)
+		}
+
+		// A short page is the end of the history, and a page with nothing older
+		// than the last would repeat forever.
+		if stop || len(page) < walletRowCount || (fromID != 0 && oldest >= fromID) {
+			break
+		}
+		fromID = oldest
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		di, dj := rows[i].rowDate(), rows[j].rowDate()
+		if di.Equal(dj) {
+			return rows[i].rowID() < rows[j].rowID()
+		}
+		return di.Before(dj)
+	})
+	return rows, nil
+}
+
+// walkJournal walks journal pages fetched by fetch.
+func walkJournal(fetch func(fromID int64) (*WalletJournalXML, error), opts WalletWalkOptions) ([]WalletJournalEntryXML, error) {
+	rows, err := walkWallet(func(fromID int64) ([]walletRow, error) {
+		w, err := fetch(fromID)
+		if err != nil {
+			return nil, err
+		}
+		page := make([]walletRow, len(w.Entries))
+		for i := range w.Entries {
+			page[i] = w.Entries[i]
+		}
+		return page, nil
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]WalletJournalEntryXML, len(rows))
+	for i, r := range rows {
+		entries[i] = r.(WalletJournalEntryXML)
+	}
+	return entries, nil
+}
+
+// walkTransactions walks transaction pages fetched by fetch.
+func walkTransactions(fetch func(fromID int64) (*WalletTransactionXML, error), opts WalletWalkOptions) ([]WalletTransactionEntryXML, error) {
+	rows, err := walkWallet(func(fromID int64) ([]walletRow, error) {
+		w, err := fetch(fromID)
+		if err != nil {
+			return nil, err
+		}
+		page := make([]walletRow, len(w.Entries))
+		for i := range w.Entries {
+			page[i] = w.Entries[i]
+		}
+		return page, nil
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]WalletTransactionEntryXML, len(rows))
+	for i, r := range rows {
+		entries[i] = r.(WalletTransactionEntryXML)
+	}
+	return entries, nil
+}
+
+// CharacterWalletJournalAllXML walks a character's wallet journal back as far as
+// the options allow and returns the entries oldest first.
+func (c *EVEAPIClient) CharacterWalletJournalAllXML(auth XMLAuth, characterID int64, opts WalletWalkOptions) ([]WalletJournalEntryXML, error) {
+	return walkJournal(func(fromID int64) (*WalletJournalXML, error) {
+		return c.CharacterWalletJournalAuthXML(auth, characterID, fromID)
+	}, opts)
+}
+
+// CharacterWalletTransactionsAllXML walks a character's wallet transactions back as
+// far as the options allow and returns them oldest first.
+func (c *EVEAPIClient) CharacterWalletTransactionsAllXML(auth XMLAuth, characterID int64, opts WalletWalkOptions) ([]WalletTransactionEntryXML, error) {
+	return walkTransactions(func(fromID int64) (*WalletTransactionXML, error) {
+		return c.CharacterWalletTransactionAuthXML(auth, characterID, fromID)
+	}, opts)
+}
+
+// CharacterWalletJournalNewerXML returns the journal entries after the highWater
+// refID, oldest first, and the refID to pass as highWater next time.
+func (c *EVEAPIClient) CharacterWalletJournalNewerXML(auth XMLAuth, characterID int64, highWater int64) ([]WalletJournalEntryXML, int64, error) {
+	entries, err := c.CharacterWalletJournalAllXML(auth, characterID, WalletWalkOptions{StopID: highWater})
+	if err != nil {
+		return nil, highWater, err
+	}
+	for _, e := range entries {
+		if e.RefID > highWater {
+			highWater = e.RefID
+		}
+	}
+	return entries, highWater, nil
+}
+
+// CharacterWalletTransactionsNewerXML returns the transactions after the highWater
+// transactionID, oldest first, and the transactionID to pass as highWater next time.
+func (c *EVEAPIClient) CharacterWalletTransactionsNewerXML(auth XMLAuth, characterID int64, highWater int64) ([]WalletTransactionEntryXML, int64, error) {
+	entries, err := c.CharacterWalletTransactionsAllXML(auth, characterID, WalletWalkOptions{StopID: highWater})
+	if err != nil {
+		return nil, highWater, err
+	}
+	for _, e := range entries {
+		if e.TransactionID > highWater {
+			highWater = e.TransactionID
+		}
+	}
+	return entries, highWater, nil
+}
@@ -0,0 +1,58 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWalletJournalWalk(t *testing.T) {
+	start := time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)
+	date := func(refID int64) time.Time { return start.Add(time.Duration(refID) * time.Minute) }
+
+	// Journal of refIDs 1001 to 4000, newest first. Pages include the fromID row
+	// to check overlapping rows are dropped.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from, _ := strconv.ParseInt(r.URL.Query().Get("fromID"), 10, 64)
+		if from == 0 {
+			from = 4000
+		}
+		var rows []string
+		for id := from; id > 1000 && len(rows) < walletRowCount; id-- {
+			rows = append(rows, fmt.Sprintf(`<row date="%s" refID="%d" refTypeID="10" amount="1" />`, date(id).Format(eveXMLTimeLayout), id))
+		}
+		fmt.Fprintf(w, `<eveapi version="2"><result><rowset name="transactions">%s</rowset></result></eveapi>`, strings.Join(rows, ""))
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{XML: srv.URL + "/"}, userAgent: USER_AGENT}
+	auth := NewXMLTokenAuth(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+
+	entries, err := c.CharacterWalletJournalAllXML(auth, 1, WalletWalkOptions{})
+	if err != nil {
+		t.Fatalf("Error walking journal %v", err)
+	}
+	if len(entries) != 3000 || entries[0].RefID != 1001 || entries[2999].RefID != 4000 {
+		t.Errorf("Expected 3000 entries oldest first, got %d", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].RefID <= entries[i-1].RefID {
+			t.Fatalf("Entries out of order at %d", i)
+		}
+	}
+
+	entries, err = c.CharacterWalletJournalAllXML(auth, 1, WalletWalkOptions{Since: date(3000)})
+	if err != nil || len(entries) != 1001 {
+		t.Errorf("Expected 1001 entries since refID 3000, got %d %v", len(entries), err)
+	}
+
+	entries, highWater, err := c.CharacterWalletJournalNewerXML(auth, 1, 3990)
+	if err != nil || len(entries) != 10 || highWater != 4000 {
+		t.Errorf("Expected 10 newer entries to 4000, got %d to %d %v", len(entries), highWater, err)
+	}
+}
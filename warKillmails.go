@@ -0,0 +1,89 @@
+package eveapi
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AllKillmailsV1 walks every page of the war's killmails and resolves each killmail
+// with workers running concurrently. Killmails with an ID at or below lastSeenID are
+// skipped so a previous crawl can be resumed.
+//
+// Killmails are returned in ID order. On error, the killmails below the first failed
+// ID are returned with the error, so the highest returned ID is safe to resume from.
+func (c *WarV1) AllKillmailsV1(lastSeenID int64, workers int) ([]*KillmailV1, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Collect the killmail references from every page, dropping duplicates.
+	seen := make(map[int64]bool)
+	var hrefs []string
+	var ids []int64
+	page, err := c.KillmailsV1()
+	for {
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, k := range page.Items {
+			id := int64(k.ID)
+			if id <= lastSeenID || seen[id] {
+				continue
+			}
+			seen[id] = true
+			hrefs = append(hrefs, k.HRef)
+			ids = append(ids, id)
+		}
+		page, err = page.NextPage()
+	}
+
+	// Resolve the killmails.
+	killmails := make([]*KillmailV1, len(hrefs))
+	errs := make([]error, len(hrefs))
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				k, err := c.EVEAPIClient.KillmailV1(hrefs[i])
+				if err == nil {
+					err = k.Verify(killmailHashFromHref(hrefs[i]))
+				}
+				killmails[i], errs[i] = k, err
+			}
+		}()
+	}
+	for i := range hrefs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Order by ID and stop at the first failure.
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return ids[order[i]] < ids[order[j]] })
+
+	result := make([]*KillmailV1, 0, len(order))
+	for _, i := range order {
+		if errs[i] != nil {
+			return result, errs[i]
+		}
+		result = append(result, killmails[i])
+	}
+	return result, nil
+}
+
+// Killmail hrefs end with killmails/{id}/{hash}/
+func killmailHashFromHref(href string) string {
+	parts := strings.Split(strings.TrimSuffix(href, "/"), "/")
+	return parts[len(parts)-1]
+}
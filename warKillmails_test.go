@@ -0,0 +1,86 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWarAllKillmails(t *testing.T) {
+	killTime, _ := time.Parse(eveKillmailTimeLayout, "2014.08.10 01:58:00")
+	hash := func(id int64) string { return GenerateKillMailHash(id, 93808108, 587, killTime) }
+
+	var srv *httptest.Server
+	mu := sync.Mutex{}
+	fetched := make(map[string]int)
+	inFlight, maxInFlight := 0, 0
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ref := func(id int64, h string) string {
+			return fmt.Sprintf(`{"href": "%s/killmails/%d/%s/", "id": %d}`, srv.URL, id, h, id)
+		}
+		switch {
+		case r.URL.Path == "/wars/1/killmails/":
+			fmt.Fprintf(w, `{"items": [%s, %s, %s], "next": {"href": "%s/wars/1/killmails/2/"}}`,
+				ref(5, hash(5)), ref(3, hash(3)), ref(7, hash(7)), srv.URL)
+		case r.URL.Path == "/wars/1/killmails/2/":
+			// Repeats killmail 7 and has a killmail that fails verification.
+			fmt.Fprintf(w, `{"items": [%s, %s, %s]}`, ref(7, hash(7)), ref(9, hash(10)), ref(8, hash(8)))
+		case strings.HasPrefix(r.URL.Path, "/killmails/"):
+			mu.Lock()
+			fetched[r.URL.Path]++
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+
+			var id int64
+			fmt.Sscanf(r.URL.Path, "/killmails/%d/", &id)
+			fmt.Fprintf(w, `{"killID": %d, "killTime": "2014.08.10 01:58:00",
+				"attackers": [{"character": {"id": 93808108}, "finalBlow": true}],
+				"victim": {"character": {"id": %d}, "shipType": {"id": 587}}}`, id, id)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{CREST: srv.URL + "/"}, userAgent: USER_AGENT}
+	war := &WarV1{EVEAPIClient: c, Killmails: srv.URL + "/wars/1/killmails/"}
+
+	// Killmails after 3 are fetched once each. Killmail 9 fails, so only those
+	// below it are returned.
+	killmails, err := war.AllKillmailsV1(3, 3)
+	if err != ErrKillmailHashMismatch {
+		t.Errorf("Expected a hash mismatch, got %v", err)
+	}
+	if len(killmails) != 3 || killmails[0].KillID != 5 || killmails[1].KillID != 7 || killmails[2].KillID != 8 {
+		t.Errorf("Wrong killmails before the failure %v", killmails)
+	}
+	if len(fetched) != 4 {
+		t.Errorf("Expected 4 killmails fetched, got %v", fetched)
+	}
+	for path, n := range fetched {
+		if n != 1 || strings.HasPrefix(path, "/killmails/3/") {
+			t.Errorf("Killmail %s fetched %d times", path, n)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > 3 {
+		t.Errorf("Expected 2 to 3 killmails fetched at once, got %d", maxInFlight)
+	}
+
+	// Resuming after the failure skips the killmails already returned.
+	fetched = make(map[string]int)
+	killmails, err = war.AllKillmailsV1(8, 1)
+	if err != ErrKillmailHashMismatch || len(killmails) != 0 || len(fetched) != 1 {
+		t.Errorf("Wrong resumed crawl %v %v %v", killmails, fetched, err)
+	}
+}
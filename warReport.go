@@ -0,0 +1,350 @@
+package eveapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Number of entries kept in report rankings.
+const warReportTopCount = 10
+
+// WarReport summarises a war, or all wars of an entity, from each side.
+type WarReport struct {
+	WarIDs    []int64       `json:"warIDs"`
+	Declared  time.Time     `json:"declared"`
+	Started   time.Time     `json:"started"`
+	Finished  *time.Time    `json:"finished,omitempty"` // Nil while a war is ongoing
+	Aggressor WarSideReport `json:"aggressor"`
+	Defender  WarSideReport `json:"defender"`
+}
+
+// WarSideReport is one side of a war. For entity reports the aggressor is the entity
+// and the defender is every opponent.
+type WarSideReport struct {
+	ID            int64            `json:"id"`
+	Name          string           `json:"name"`
+	Kills         int              `json:"kills"`
+	Losses        int              `json:"losses"`
+	ISKKilled     float64          `json:"iskKilled"`
+	ISKLost       float64          `json:"iskLost"`
+	ISKEfficiency float64          `json:"iskEfficiency"` // Percent of ISK destroyed that was killed by this side
+	Timeline      []WarReportDay   `json:"timeline"`
+	TopShips      []WarReportEntry `json:"topShips"`  // Ship types killed by this side
+	TopPilots     []WarReportEntry `json:"topPilots"` // Pilots on the most kills
+	Allies        []WarReportEntry `json:"allies,omitempty"`
+}
+
+// WarReportDay is the kills and losses of a side on one day.
+type WarReportDay struct {
+	Date   time.Time `json:"date"`
+	Kills  int       `json:"kills"`
+	Losses int       `json:"losses"`
+}
+
+// WarReportEntry ranks a ship type, pilot or ally by kills.
+type WarReportEntry struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Kills int     `json:"kills"`
+	Value float64 `json:"value,omitempty"` // ISK value of the kills, if priced
+}
+
+// Accumulates one side of a war.
+type warSideBuilder struct {
+	id        int64
+	name      string
+	iskKilled float64
+	iskLost   float64
+	kills     int
+	losses    int
+	days      map[time.Time]*WarReportDay
+	ships     map[int64]*WarReportEntry
+	pilots    map[int64]*WarReportEntry
+	allies    map[int64]*WarReportEntry
+}
+
+func newWarSideBuilder(id int64, name string) *warSideBuilder {
+	return &warSideBuilder{
+		id:     id,
+		name:   name,
+		days:   make(map[time.Time]*WarReportDay),
+		ships:  make(map[int64]*WarReportEntry),
+		pilots: make(map[int64]*WarReportEntry),
+		allies: make(map[int64]*WarReportEntry),
+	}
+}
+
+func (b *warSideBuilder) day(t time.Time) *WarReportDay {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if b.days[d] == nil {
+		b.days[d] = &WarReportDay{Date: d}
+	}
+	return b.days[d]
+}
+
+func addWarReportEntry(m map[int64]*WarReportEntry, ref namedReference, value float64) {
+	if ref.ID == 0 {
+		return
+	}
+	if m[ref.ID] == nil {
+		m[ref.ID] = &WarReportEntry{ID: ref.ID, Name: ref.Name}
+	}
+	m[ref.ID].Kills++
+	m[ref.ID].Value += value
+}
+
+// Record a kill made by this side. allies are the ally IDs fighting for this side.
+func (b *warSideBuilder) addKill(k *KillmailV1, value float64, allies map[int64]bool) {
+	b.kills++
+	b.day(k.KillTime.Time).Kills++
+	addWarReportEntry(b.ships, k.Victim.ShipType, value)
+
+	pilots := make(map[int64]bool)
+	involved := make(map[int64]bool)
+	for _, a := range k.Attackers {
+		if !pilots[a.Character.ID] {
+			pilots[a.Character.ID] = true
+			addWarReportEntry(b.pilots, a.Character, value)
+		}
+		for _, e := range []namedReference{a.Corporation, a.Alliance} {
+			if allies[e.ID] && !involved[e.ID] {
+				involved[e.ID] = true
+				addWarReportEntry(b.allies, e, value)
+			}
+		}
+	}
+}
+
+// Record a loss of this side.
+func (b *warSideBuilder) addLoss(k *KillmailV1) {
+	b.losses++
+	b.day(k.KillTime.Time).Losses++
+}
+
+func (b *warSideBuilder) report() WarSideReport {
+	r := WarSideReport{
+		ID:        b.id,
+		Name:      b.name,
+		Kills:     b.kills,
+		Losses:    b.losses,
+		ISKKilled: b.iskKilled,
+		ISKLost:   b.iskLost,
+		TopShips:  rankWarReportEntries(b.ships),
+		TopPilots: rankWarReportEntries(b.pilots),
+		Allies:    rankWarReportEntries(b.allies),
+	}
+	if total := b.iskKilled + b.iskLost; total > 0 {
+		r.ISKEfficiency = b.iskKilled / total * 100
+	}
+
+	for _, d := range b.days {
+		r.Timeline = append(r.Timeline, *d)
+	}
+	sort.Slice(r.Timeline, func(i, j int) bool { return r.Timeline[i].Date.Before(r.Timeline[j].Date) })
+	return r
+}
+
+func rankWarReportEntries(m map[int64]*WarReportEntry) []WarReportEntry {
+	var entries []WarReportEntry
+	for _, e := range m {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kills != entries[j].Kills {
+			return entries[i].Kills > entries[j].Kills
+		}
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	if len(entries) > warReportTopCount {
+		entries = entries[:warReportTopCount]
+	}
+	return entries
+}
+
+// Value of a killmail at sell prices, or zero without prices.
+func killmailValue(k *KillmailV1, prices MarketPrices) float64 {
+	if prices == nil {
+		return 0
+	}
+	var value func(items []KillmailItemV1) float64
+	value = func(items []KillmailItemV1) float64 {
+		var v float64
+		for _, i := range items {
+			v += prices[i.ItemType.ID].Sell*float64(i.QuantityDestroyed+i.QuantityDropped) + value(i.Items)
+		}
+		return v
+	}
+	return prices[k.Victim.ShipType.ID].Sell + value(k.Victim.Items)
+}
+
+func killmailVictimIn(k *KillmailV1, ids map[int64]bool) bool {
+	return ids[k.Victim.Corporation.ID] || ids[k.Victim.Alliance.ID]
+}
+
+// Add a war to an aggressor and defender builder. swap puts the war's defender on the
+// aggressor builder.
+func addWarToReport(aggressor, defender *warSideBuilder, war *WarV1, killmails []*KillmailV1, prices MarketPrices, swap bool) {
+	// a and d always hold the war's aggressor and defender.
+	a, d := aggressor, defender
+	if swap {
+		a, d = defender, aggressor
+	}
+
+	// Allies fight for the war's defender, whichever builder that is.
+	aIDs := map[int64]bool{war.Aggressor.ID: true}
+	dIDs := map[int64]bool{war.Defender.ID: true}
+	dAllies := make(map[int64]bool)
+	for _, ally := range war.Allies {
+		dIDs[ally.ID] = true
+		dAllies[ally.ID] = true
+	}
+
+	a.iskKilled += war.Aggressor.IskKilled
+	a.iskLost += war.Defender.IskKilled
+	d.iskKilled += war.Defender.IskKilled
+	d.iskLost += war.Aggressor.IskKilled
+
+	for _, k := range killmails {
+		value := killmailValue(k, prices)
+		switch {
+		case killmailVictimIn(k, aIDs):
+			a.addLoss(k)
+			d.addKill(k, value, dAllies)
+		case killmailVictimIn(k, dIDs):
+			d.addLoss(k)
+			a.addKill(k, value, nil)
+		}
+	}
+}
+
+// BuildWarReport builds a report of a war from its killmails. ISK totals come from the war.
+// If prices are given, rankings include the value of the kills.
+func BuildWarReport(war *WarV1, killmails []*KillmailV1, prices MarketPrices) *WarReport {
+	a := newWarSideBuilder(war.Aggressor.ID, war.Aggressor.Name)
+	d := newWarSideBuilder(war.Defender.ID, war.Defender.Name)
+	addWarToReport(a, d, war, killmails, prices, false)
+
+	r := &WarReport{
+		WarIDs:    []int64{war.ID},
+		Declared:  war.TimeDeclared.Time,
+		Started:   war.TimeStarted.Time,
+		Aggressor: a.report(),
+		Defender:  d.report(),
+	}
+	if !war.TimeFinished.IsZero() {
+		finished := war.TimeFinished.Time
+		r.Finished = &finished
+	}
+	return r
+}
+
+// BuildEntityWarReport builds a report of every war an entity fought. The entity is
+// reported as the aggressor and all of its opponents as the defender. killmails maps
+// war IDs to their killmails. The report is finished when the last war finished, or
+// not at all while any war is ongoing.
+func BuildEntityWarReport(entityID int64, entityName string, wars []*WarV1, killmails map[int64][]*KillmailV1, prices MarketPrices) *WarReport {
+	us := newWarSideBuilder(entityID, entityName)
+	them := newWarSideBuilder(0, "Opponents")
+	r := &WarReport{}
+	ongoing := false
+
+	for _, war := range wars {
+		addWarToReport(us, them, war, killmails[war.ID], prices, war.Aggressor.ID != entityID)
+		r.WarIDs = append(r.WarIDs, war.ID)
+
+		if r.Declared.IsZero() || war.TimeDeclared.Before(r.Declared) {
+			r.Declared = war.TimeDeclared.Time
+		}
+		if r.Started.IsZero() || war.TimeStarted.Before(r.Started) {
+			r.Started = war.TimeStarted.Time
+		}
+		if war.TimeFinished.IsZero() {
+			ongoing = true
+		} else if r.Finished == nil || war.TimeFinished.After(*r.Finished) {
+			finished := war.TimeFinished.Time
+			r.Finished = &finished
+		}
+	}
+	if ongoing {
+		r.Finished = nil
+	}
+
+	r.Aggressor = us.report()
+	r.Defender = them.report()
+	return r
+}
+
+// ReportV1 fetches every killmail of the war and builds its report.
+func (c *WarV1) ReportV1(workers int, prices MarketPrices) (*WarReport, error) {
+	killmails, err := c.AllKillmailsV1(0, workers)
+	if err != nil {
+		return nil, err
+	}
+	return BuildWarReport(c, killmails, prices), nil
+}
+
+// JSON renders the report as indented JSON.
+func (r *WarReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Table renders the report as a plain text table.
+func (r *WarReport) Table() string {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 4, 2, ' ', 0)
+	a, d := &r.Aggressor, &r.Defender
+
+	fmt.Fprintf(w, "\t%s\t%s\n", a.Name, d.Name)
+	fmt.Fprintf(w, "Kills\t%d\t%d\n", a.Kills, d.Kills)
+	fmt.Fprintf(w, "Losses\t%d\t%d\n", a.Losses, d.Losses)
+	fmt.Fprintf(w, "ISK Killed\t%s\t%s\n", formatISK(a.ISKKilled), formatISK(d.ISKKilled))
+	fmt.Fprintf(w, "ISK Lost\t%s\t%s\n", formatISK(a.ISKLost), formatISK(d.ISKLost))
+	fmt.Fprintf(w, "Efficiency\t%.1f%%\t%.1f%%\n", a.ISKEfficiency, d.ISKEfficiency)
+
+	for _, s := range []struct {
+		title string
+		a, d  []WarReportEntry
+	}{
+		{"Top Ships", a.TopShips, d.TopShips},
+		{"Top Pilots", a.TopPilots, d.TopPilots},
+		{"Allies", a.Allies, d.Allies},
+	} {
+		if len(s.a) == 0 && len(s.d) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\t\t\n%s\t\t\n", s.title)
+		for i := 0; i < len(s.a) || i < len(s.d); i++ {
+			fmt.Fprintf(w, "\t%s\t%s\n", warReportEntryCell(s.a, i), warReportEntryCell(s.d, i))
+		}
+	}
+
+	w.Flush()
+	return buf.String()
+}
+
+func warReportEntryCell(entries []WarReportEntry, i int) string {
+	if i >= len(entries) {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d)", entries[i].Name, entries[i].Kills)
+}
+
+// Format ISK values in billions, millions or thousands.
+func formatISK(isk float64) string {
+	switch {
+	case isk >= 1e9:
+		return fmt.Sprintf("%.2fb", isk/1e9)
+	case isk >= 1e6:
+		return fmt.Sprintf("%.2fm", isk/1e6)
+	case isk >= 1e3:
+		return fmt.Sprintf("%.2fk", isk/1e3)
+	}
+	return fmt.Sprintf("%.2f", isk)
+}
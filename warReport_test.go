@@ -0,0 +1,125 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testReportWar = `{
+	"id": 1,
+	"timeDeclared": "2016-01-01T00:00:00",
+	"timeStarted": "2016-01-02T00:00:00",
+	"aggressor": {"id": 1001, "name": "Aggressor", "iskKilled": 300},
+	"defender": {"id": 2001, "name": "Defender", "iskKilled": 100},
+	"allies": [{"id": 3001, "name": "Ally"}]
+}`
+
+const testReportKillmails = `[
+	{"killID": 1, "killTime": "2016.01.02 10:00:00",
+		"victim": {"corporation": {"id": 2001}, "shipType": {"id": 587, "name": "Rifter"}},
+		"attackers": [{"character": {"id": 11, "name": "A1"}, "corporation": {"id": 1001}}]},
+	{"killID": 2, "killTime": "2016.01.02 11:00:00",
+		"victim": {"corporation": {"id": 1001}, "shipType": {"id": 603, "name": "Merlin"}},
+		"attackers": [{"character": {"id": 21, "name": "D1"}, "corporation": {"id": 2001}},
+			{"character": {"id": 31, "name": "Ally Pilot"}, "corporation": {"id": 5000}, "alliance": {"id": 3001, "name": "Ally"}}]},
+	{"killID": 3, "killTime": "2016.01.03 10:00:00",
+		"victim": {"corporation": {"id": 5000}, "alliance": {"id": 3001}, "shipType": {"id": 587, "name": "Rifter"}},
+		"attackers": [{"character": {"id": 11, "name": "A1"}, "corporation": {"id": 1001}},
+			{"character": {"id": 12, "name": "A2"}, "corporation": {"id": 1001}}]},
+	{"killID": 4, "killTime": "2016.01.03 11:00:00",
+		"victim": {"corporation": {"id": 9999}, "shipType": {"id": 587, "name": "Rifter"}},
+		"attackers": [{"character": {"id": 11, "name": "A1"}, "corporation": {"id": 1001}}]}
+]`
+
+func testWarReportData(t *testing.T) (*WarV1, []*KillmailV1) {
+	war := &WarV1{}
+	if err := json.Unmarshal([]byte(testReportWar), war); err != nil {
+		t.Fatalf("Error decoding war %v", err)
+	}
+	var killmails []*KillmailV1
+	if err := json.Unmarshal([]byte(testReportKillmails), &killmails); err != nil {
+		t.Fatalf("Error decoding killmails %v", err)
+	}
+	return war, killmails
+}
+
+func TestBuildWarReport(t *testing.T) {
+	war, killmails := testWarReportData(t)
+	r := BuildWarReport(war, killmails, MarketPrices{587: {Sell: 1000}, 603: {Sell: 2000}})
+
+	a, d := r.Aggressor, r.Defender
+	if a.Kills != 2 || a.Losses != 1 || d.Kills != 1 || d.Losses != 2 {
+		t.Errorf("Wrong kills and losses %d/%d %d/%d", a.Kills, a.Losses, d.Kills, d.Losses)
+	}
+	if a.ISKKilled != 300 || a.ISKLost != 100 || a.ISKEfficiency != 75 || d.ISKEfficiency != 25 {
+		t.Errorf("Wrong ISK totals %+v", a)
+	}
+	day := time.Date(2016, 1, 2, 0, 0, 0, 0, time.UTC)
+	if len(a.Timeline) != 2 || !a.Timeline[0].Date.Equal(day) || a.Timeline[0].Kills != 1 || a.Timeline[0].Losses != 1 || a.Timeline[1].Kills != 1 {
+		t.Errorf("Wrong timeline %+v", a.Timeline)
+	}
+	if len(a.TopShips) != 1 || a.TopShips[0].ID != 587 || a.TopShips[0].Kills != 2 || a.TopShips[0].Value != 2000 {
+		t.Errorf("Wrong top ships %+v", a.TopShips)
+	}
+	if len(a.TopPilots) != 2 || a.TopPilots[0].ID != 11 || a.TopPilots[0].Kills != 2 {
+		t.Errorf("Wrong top pilots %+v", a.TopPilots)
+	}
+	if len(d.Allies) != 1 || d.Allies[0].ID != 3001 || d.Allies[0].Kills != 1 || len(a.Allies) != 0 {
+		t.Errorf("Wrong allies %+v %+v", d.Allies, a.Allies)
+	}
+
+	// Ongoing wars have no finish time.
+	buf, err := r.JSON()
+	if err != nil {
+		t.Fatalf("Error rendering JSON %v", err)
+	}
+	if strings.Contains(string(buf), `"finished"`) {
+		t.Errorf("Ongoing war has a finish time")
+	}
+	war.TimeFinished.Time = time.Date(2016, 1, 10, 0, 0, 0, 0, time.UTC)
+	if r := BuildWarReport(war, killmails, nil); r.Finished == nil || !r.Finished.Equal(war.TimeFinished.Time) {
+		t.Errorf("Wrong finish time %v", r.Finished)
+	}
+
+	table := r.Table()
+	if !strings.Contains(table, "Aggressor") || !strings.Contains(table, "A1 (2)") || !strings.Contains(table, "Ally (1)") {
+		t.Errorf("Wrong table\n%s", table)
+	}
+}
+
+func TestBuildEntityWarReport(t *testing.T) {
+	war, killmails := testWarReportData(t)
+	other := &WarV1{ID: 2}
+	other.Aggressor.ID, other.Aggressor.IskKilled = 2001, 50
+	other.Defender.ID = 4001
+	other.TimeFinished.Time = time.Date(2016, 2, 1, 0, 0, 0, 0, time.UTC)
+	otherKillmails := []*KillmailV1{{}}
+	otherKillmails[0].Victim.Corporation.ID = 4001
+	otherKillmails[0].Attackers = []KillmailAttackerV1{{}}
+	otherKillmails[0].Attackers[0].Character.ID = 21
+
+	// The defender of war 1 is reported as the aggressor, with its ally's kills
+	// and losses on its side.
+	r := BuildEntityWarReport(2001, "Defender", []*WarV1{war, other}, map[int64][]*KillmailV1{1: killmails, 2: otherKillmails}, nil)
+	us, them := r.Aggressor, r.Defender
+	if us.Kills != 2 || us.Losses != 2 || them.Kills != 2 || them.Losses != 2 {
+		t.Errorf("Wrong kills and losses %d/%d %d/%d", us.Kills, us.Losses, them.Kills, them.Losses)
+	}
+	if us.ISKKilled != 150 || us.ISKLost != 300 {
+		t.Errorf("Wrong ISK totals %+v", us)
+	}
+	if len(us.Allies) != 1 || us.Allies[0].ID != 3001 || len(them.Allies) != 0 {
+		t.Errorf("Ally kills credited to the wrong side %+v %+v", us.Allies, them.Allies)
+	}
+	if len(r.WarIDs) != 2 || r.Finished != nil {
+		t.Errorf("Wrong wars %v finished %v", r.WarIDs, r.Finished)
+	}
+
+	war.TimeFinished.Time = time.Date(2016, 1, 10, 0, 0, 0, 0, time.UTC)
+	r = BuildEntityWarReport(2001, "Defender", []*WarV1{war, other}, nil, nil)
+	if r.Finished == nil || !r.Finished.Equal(other.TimeFinished.Time) {
+		t.Errorf("Wrong finish time %v", r.Finished)
+	}
+}
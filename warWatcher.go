@@ -0,0 +1,343 @@
+package eveapi
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WarEventType is the kind of change seen on a war.
+type WarEventType int
+
+const (
+	WarDeclared WarEventType = iota
+	WarStarted
+	WarFinished
+	WarMutual
+	WarAllyJoined
+)
+
+func (t WarEventType) String() string {
+	switch t {
+	case WarDeclared:
+		return "declared"
+	case WarStarted:
+		return "started"
+	case WarFinished:
+		return "finished"
+	case WarMutual:
+		return "mutual"
+	case WarAllyJoined:
+		return "ally joined"
+	}
+	return "unknown"
+}
+
+// WarEvent is sent by a WarWatcher when a war changes.
+type WarEvent struct {
+	Type    WarEventType
+	War     *WarV1
+	AllyIDs []int64 // Allies that joined, for WarAllyJoined
+}
+
+// WarState is the last known state of a war.
+type WarState struct {
+	ID        int64
+	Ignored   bool // The war does not involve any watched entity
+	Started   bool
+	Finished  bool
+	Mutual    bool
+	AllyIDs   []int64
+	NextCheck time.Time
+}
+
+// WarStateStore persists war states between runs of a WarWatcher.
+type WarStateStore interface {
+	// GetWarState returns the state of a war, or nil if the war is unknown.
+	GetWarState(warID int64) (*WarState, error)
+	PutWarState(state *WarState) error
+}
+
+// MemoryWarStateStore is a WarStateStore that does not persist.
+type MemoryWarStateStore struct {
+	mu   sync.Mutex
+	wars map[int64]WarState
+}
+
+func NewMemoryWarStateStore() *MemoryWarStateStore {
+	return &MemoryWarStateStore{wars: make(map[int64]WarState)}
+}
+
+func (s *MemoryWarStateStore) GetWarState(warID int64) (*WarState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.wars[warID]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *MemoryWarStateStore) PutWarState(state *WarState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wars[state.ID] = *state
+	return nil
+}
+
+// FileWarStateStore is a WarStateStore kept in a file so a WarWatcher resumes
+// where it left off after a restart. States are appended to the file as they
+// change and the file is compacted when opened.
+type FileWarStateStore struct {
+	*MemoryWarStateStore
+	log *jsonLog
+}
+
+// NewFileWarStateStore opens a file store, loading the file if it exists.
+func NewFileWarStateStore(path string) (*FileWarStateStore, error) {
+	s := &FileWarStateStore{MemoryWarStateStore: NewMemoryWarStateStore()}
+
+	replay := func(dec *json.Decoder) error {
+		state := WarState{}
+		if err := dec.Decode(&state); err != nil {
+			return err
+		}
+		s.wars[state.ID] = state
+		return nil
+	}
+	compact := func(enc *json.Encoder) error {
+		ids := make([]int64, 0, len(s.wars))
+		for id := range s.wars {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			state := s.wars[id]
+			if err := enc.Encode(&state); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	log, err := openJSONLog(path, replay, compact)
+	if err != nil {
+		return nil, err
+	}
+	s.log = log
+	return s, nil
+}
+
+func (s *FileWarStateStore) PutWarState(state *WarState) error {
+	s.MemoryWarStateStore.PutWarState(state)
+	return s.log.append(state)
+}
+
+// Close closes the file.
+func (s *FileWarStateStore) Close() error {
+	return s.log.close()
+}
+
+// WarWatcher polls the wars collection and sends events when wars involving the
+// watched entities are declared, start, finish, become mutual or gain allies.
+type WarWatcher struct {
+	client   *EVEAPIClient
+	store    WarStateStore
+	entities map[int64]bool
+	events   chan WarEvent
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	// MinWarID skips older wars so the first poll does not fetch every war.
+	MinWarID int64
+	// Interval is the minimum time between polls.
+	Interval time.Duration
+	// OnError is called when a poll fails, if set.
+	OnError func(err error)
+}
+
+var errWarWatcherStopped = errors.New("war watcher stopped")
+
+// NewWarWatcher creates a watcher for wars where one of the entityIDs is the aggressor
+// or defender. With no entityIDs every war is watched. Wars with an aggressor or
+// defender that is not watched are not checked again, so an entity joining as an ally
+// is only seen on wars that are already watched.
+func NewWarWatcher(c *EVEAPIClient, store WarStateStore, entityIDs ...int64) *WarWatcher {
+	w := &WarWatcher{
+		client:   c,
+		store:    store,
+		entities: make(map[int64]bool),
+		events:   make(chan WarEvent, 100),
+		stop:     make(chan struct{}),
+		Interval: time.Hour,
+	}
+	for _, id := range entityIDs {
+		w.entities[id] = true
+	}
+	return w
+}
+
+// Events returns the channel of war events. The channel is closed by Stop.
+func (w *WarWatcher) Events() <-chan WarEvent {
+	return w.events
+}
+
+// Start polls in the background until Stop is called.
+func (w *WarWatcher) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		for {
+			next, err := w.Poll()
+			if err == errWarWatcherStopped {
+				return
+			}
+			if err != nil && w.OnError != nil {
+				w.OnError(err)
+			}
+			select {
+			case <-w.stop:
+				return
+			case <-time.After(next.Sub(time.Now())):
+			}
+		}
+	}()
+}
+
+// Stop stops polling and closes the events channel. Further calls do nothing.
+func (w *WarWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		w.wg.Wait()
+		close(w.events)
+	})
+}
+
+// Poll walks the wars collection once, checking wars that are due, and returns the
+// time of the next poll.
+func (w *WarWatcher) Poll() (time.Time, error) {
+	now := time.Now()
+	next := now.Add(w.Interval)
+
+	page, err := w.client.WarsV1(1)
+	if err == nil && page != nil && page.CacheUntil.After(next) {
+		next = page.CacheUntil
+	}
+
+	for {
+		if err != nil {
+			return next, err
+		}
+		if page == nil {
+			return next, nil
+		}
+
+		for _, item := range page.Items {
+			id := int64(item.ID)
+			if id < w.MinWarID {
+				continue
+			}
+			if err := w.check(id, item.HRef, now); err != nil {
+				return next, err
+			}
+		}
+		page, err = page.NextPage()
+	}
+}
+
+// Check a war if it is due and send any events.
+func (w *WarWatcher) check(id int64, href string, now time.Time) error {
+	state, err := w.store.GetWarState(id)
+	if err != nil {
+		return err
+	}
+	if state != nil && (state.Ignored || state.Finished || now.Before(state.NextCheck)) {
+		return nil
+	}
+
+	war, err := w.client.WarV1(href)
+	if err != nil {
+		return err
+	}
+
+	if state == nil && !w.watching(war) {
+		return w.store.PutWarState(&WarState{ID: id, Ignored: true})
+	}
+
+	newState, events := diffWarState(state, war, now)
+	if err := w.store.PutWarState(newState); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		select {
+		case w.events <- e:
+		case <-w.stop:
+			return errWarWatcherStopped
+		}
+	}
+	return nil
+}
+
+func (w *WarWatcher) watching(war *WarV1) bool {
+	if len(w.entities) == 0 {
+		return true
+	}
+	return w.entities[war.Aggressor.ID] || w.entities[war.Defender.ID]
+}
+
+// Compare a war to its last state. A nil state is a newly seen war.
+func diffWarState(state *WarState, war *WarV1, now time.Time) (*WarState, []WarEvent) {
+	var events []WarEvent
+	newState := &WarState{ID: war.ID}
+	if state == nil {
+		state = &WarState{ID: war.ID}
+		events = append(events, WarEvent{Type: WarDeclared, War: war})
+	}
+
+	started := !war.TimeStarted.IsZero() && !now.Before(war.TimeStarted.Time)
+	finished := !war.TimeFinished.IsZero() && !now.Before(war.TimeFinished.Time)
+
+	if started && !state.Started {
+		events = append(events, WarEvent{Type: WarStarted, War: war})
+	}
+	if war.Mutual && !state.Mutual {
+		events = append(events, WarEvent{Type: WarMutual, War: war})
+	}
+
+	known := make(map[int64]bool)
+	for _, id := range state.AllyIDs {
+		known[id] = true
+	}
+	var joined []int64
+	for _, a := range war.Allies {
+		newState.AllyIDs = append(newState.AllyIDs, a.ID)
+		if !known[a.ID] {
+			joined = append(joined, a.ID)
+		}
+	}
+	if len(joined) > 0 {
+		events = append(events, WarEvent{Type: WarAllyJoined, War: war, AllyIDs: joined})
+	}
+
+	if finished && !state.Finished {
+		events = append(events, WarEvent{Type: WarFinished, War: war})
+	}
+
+	newState.Started = state.Started || started
+	newState.Finished = state.Finished || finished
+	newState.Mutual = state.Mutual || war.Mutual
+
+	// Check again when the cache expires, or sooner if the war starts or finishes first.
+	newState.NextCheck = war.CacheUntil
+	for _, t := range []time.Time{war.TimeStarted.Time, war.TimeFinished.Time} {
+		if t.After(now) && (newState.NextCheck.IsZero() || t.Before(newState.NextCheck)) {
+			newState.NextCheck = t
+		}
+	}
+
+	return newState, events
+}
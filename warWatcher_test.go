@@ -0,0 +1,111 @@
+package eveapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testWar = `{
+	"timeDeclared": "2016-01-01T00:00:00",
+	"timeStarted": "2016-01-02T00:00:00",
+	"aggressor": {"id": 1000001, "name": "Aggressor"},
+	"defender": {"id": 1000002, "name": "Defender"},
+	"allies": [{"id": 1000003, "name": "Ally"}],
+	"mutual": false,
+	"id": 1
+}`
+
+func TestWarStateEvents(t *testing.T) {
+	war := &WarV1{}
+	if err := json.Unmarshal([]byte(testWar), war); err != nil {
+		t.Fatalf("Error decoding war %v", err)
+	}
+
+	// Before the war starts only the declaration is seen.
+	before := time.Date(2016, 1, 1, 12, 0, 0, 0, time.UTC)
+	state, events := diffWarState(nil, war, before)
+	if len(events) != 2 || events[0].Type != WarDeclared || events[1].Type != WarAllyJoined {
+		t.Fatalf("Unexpected events for new war %v", events)
+	}
+	if !state.NextCheck.Equal(war.TimeStarted.Time) {
+		t.Errorf("Next check %v should be the war start", state.NextCheck)
+	}
+
+	// Once started with no other changes, only the start is seen.
+	after := time.Date(2016, 1, 2, 12, 0, 0, 0, time.UTC)
+	state, events = diffWarState(state, war, after)
+	if len(events) != 1 || events[0].Type != WarStarted {
+		t.Fatalf("Unexpected events for started war %v", events)
+	}
+
+	// Nothing changed.
+	if _, events = diffWarState(state, war, after); len(events) != 0 {
+		t.Errorf("Unexpected events for unchanged war %v", events)
+	}
+
+	// The war turns mutual and finishes.
+	war.Mutual = true
+	war.TimeFinished.Time = after
+	_, events = diffWarState(state, war, after)
+	if len(events) != 2 || events[0].Type != WarMutual || events[1].Type != WarFinished {
+		t.Errorf("Unexpected events for finished war %v", events)
+	}
+}
+
+func TestFileWarStateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eveapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "wars.json")
+
+	s, err := NewFileWarStateStore(path)
+	if err != nil {
+		t.Fatalf("Error opening store %v", err)
+	}
+	next := time.Date(2016, 1, 2, 0, 0, 0, 0, time.UTC)
+	s.PutWarState(&WarState{ID: 1, Started: true, AllyIDs: []int64{3}})
+	s.PutWarState(&WarState{ID: 2, Ignored: true})
+	s.PutWarState(&WarState{ID: 1, Started: true, Finished: true, AllyIDs: []int64{3, 4}, NextCheck: next})
+	s.Close()
+
+	for i := 0; i < 2; i++ {
+		s, err = NewFileWarStateStore(path)
+		if err != nil {
+			t.Fatalf("Error reopening store %v", err)
+		}
+		if state, _ := s.GetWarState(1); state == nil || !state.Finished || len(state.AllyIDs) != 2 || !state.NextCheck.Equal(next) {
+			t.Errorf("Latest war state not persisted %+v", state)
+		}
+		if state, _ := s.GetWarState(2); state == nil || !state.Ignored {
+			t.Errorf("Ignored war not persisted %+v", state)
+		}
+		if state, _ := s.GetWarState(3); state != nil {
+			t.Errorf("Unknown war has a state %+v", state)
+		}
+		s.Close()
+	}
+
+	// Reopening compacts the file to one state per war.
+	buf, _ := ioutil.ReadFile(path)
+	if lines := len(bytes.Split(bytes.TrimSpace(buf), []byte("\n"))); lines != 2 {
+		t.Errorf("Expected 2 states in the compacted file, got %d", lines)
+	}
+}
+
+func TestWarWatcherStopTwice(t *testing.T) {
+	c := &EVEAPIClient{httpClient: http.DefaultClient}
+	w := NewWarWatcher(c, NewMemoryWarStateStore())
+	w.Stop()
+	w.Stop()
+	if _, ok := <-w.Events(); ok {
+		t.Errorf("Events not closed")
+	}
+}
@@ -118,11 +118,29 @@ func (c *EVEAPIClient) WarByID(id int) (*WarV1, error) {
 
 // GetKillmails provides a list of killmails associated to this war.
 func (c *WarV1) KillmailsV1() (*WarKillmailsV1, error) {
-	w := &WarKillmailsV1{EVEAPIClient: c.EVEAPIClient}
-	res, err := c.doJSON("GET", c.Killmails, nil, w, warKillmailsV1Type, nil)
+	return c.EVEAPIClient.warKillmailsV1(c.Killmails)
+}
+
+func (c *EVEAPIClient) warKillmailsV1(href string) (*WarKillmailsV1, error) {
+	w := &WarKillmailsV1{EVEAPIClient: c}
+	res, err := c.doJSON("GET", href, nil, w, warKillmailsV1Type, nil)
 	if err != nil {
 		return nil, err
 	}
-	w.getFrameInfo(c.Killmails, res)
+	w.getFrameInfo(href, res)
 	return w, nil
 }
+
+func (c *WarKillmailsV1) NextPage() (*WarKillmailsV1, error) {
+	if c.Next.HRef == "" {
+		return nil, nil
+	}
+	return c.warKillmailsV1(c.Next.HRef)
+}
+
+func (c *WarKillmailsV1) PreviousPage() (*WarKillmailsV1, error) {
+	if c.Previous.HRef == "" {
+		return nil, nil
+	}
+	return c.warKillmailsV1(c.Previous.HRef)
+}
@@ -0,0 +1,105 @@
+package eveapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrXMLKeyType      = errors.New("API key type does not allow this call")
+	ErrXMLKeyMask      = errors.New("API key access mask does not allow this call")
+	ErrXMLKeyExpired   = errors.New("API key has expired")
+	ErrXMLKeyCharacter = errors.New("API key does not include this character")
+)
+
+// XMLAuth authenticates private XML API calls, either with an *XMLAPIKey or with
+// an SSO token source wrapped by NewXMLTokenAuth.
+type XMLAuth interface {
+	// xmlAuthorize checks the call is allowed and returns the query parameters
+	// that authenticate it.
+	xmlAuthorize(c *EVEAPIClient, call xmlCall, characterID int64) (url.Values, error)
+}
+
+// xmlCall is a private XML API call, named as in the call list.
+type xmlCall struct {
+	path    string
+	keyType XMLKeyType
+	name    string
+}
+
+var (
+	xmlCharWalletJournal      = xmlCall{"char/WalletJournal.xml.aspx", XMLKeyCharacter, "WalletJournal"}
+	xmlCharWalletTransactions = xmlCall{"char/WalletTransactions.xml.aspx", XMLKeyCharacter, "WalletTransactions"}
+	xmlCharAssetList          = xmlCall{"char/AssetList.xml.aspx", XMLKeyCharacter, "AssetList"}
+	xmlCorpAccountBalance     = xmlCall{"corp/AccountBalance.xml.aspx", XMLKeyCorporation, "AccountBalance"}
+	xmlCorpAssetList          = xmlCall{"corp/AssetList.xml.aspx", XMLKeyCorporation, "AssetList"}
+	xmlCorpSheet              = xmlCall{"corp/CorporationSheet.xml.aspx", XMLKeyCorporation, "CorporationSheet"}
+	xmlCorpWalletJournal      = xmlCall{"corp/WalletJournal.xml.aspx", XMLKeyCorporation, "WalletJournal"}
+	xmlCorpWalletTransactions = xmlCall{"corp/WalletTransactions.xml.aspx", XMLKeyCorporation, "WalletTransactions"}
+)
+
+type xmlTokenAuth struct {
+	ts oauth2.TokenSource
+}
+
+// NewXMLTokenAuth authenticates XML API calls with SSO access tokens. Scopes are
+// checked by the server, so calls are not checked before the request.
+func NewXMLTokenAuth(ts oauth2.TokenSource) XMLAuth {
+	return &xmlTokenAuth{ts}
+}
+
+func (a *xmlTokenAuth) xmlAuthorize(c *EVEAPIClient, call xmlCall, characterID int64) (url.Values, error) {
+	tok, err := a.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{"accessToken": {tok.AccessToken}}
+	// Tokens are for a character, so say when the call is for their corporation.
+	if call.keyType == XMLKeyCorporation {
+		q.Set("accessType", "corporation")
+	}
+	return q, nil
+}
+
+func (k *XMLAPIKey) xmlAuthorize(c *EVEAPIClient, call xmlCall, characterID int64) (url.Values, error) {
+	info, err := k.cachedInfo(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if callType(info.Key.Type) != call.keyType {
+		return nil, ErrXMLKeyType
+	}
+	if !AccessMask(info.Key.AccessMask).Allows(call.name, call.keyType) {
+		return nil, ErrXMLKeyMask
+	}
+	if !info.Key.Expires.IsZero() && time.Now().After(info.Key.Expires.Time) {
+		return nil, ErrXMLKeyExpired
+	}
+	if characterID != 0 && !info.HasCharacter(characterID) {
+		return nil, ErrXMLKeyCharacter
+	}
+
+	return url.Values{
+		"keyID": {fmt.Sprintf("%d", k.KeyID)},
+		"vCode": {k.VCode},
+	}, nil
+}
+
+// doAuthedXML checks the call against auth and requests it with params.
+func (c *EVEAPIClient) doAuthedXML(auth XMLAuth, call xmlCall, characterID int64, params url.Values, v interface{}) error {
+	q, err := auth.xmlAuthorize(c, call, characterID)
+	if err != nil {
+		return err
+	}
+	for k, vs := range params {
+		q[k] = vs
+	}
+
+	_, err = c.doXML("GET", c.base.XML+call.path+"?"+q.Encode(), nil, v, nil, nil)
+	return err
+}
@@ -0,0 +1,89 @@
+package eveapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestXMLAPIKeyChecks(t *testing.T) {
+	var keyType, accessMask string
+	journalRequests, infoRequests := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account/APIKeyInfo.xml.aspx":
+			infoRequests++
+			fmt.Fprintf(w, `<eveapi version="2"><result><key accessMask="%s" type="%s" expires="">
+				<rowset name="characters"><row characterID="90000001" characterName="Alpha" corporationID="1000" corporationName="Corp" /></rowset>
+				</key></result></eveapi>`, accessMask, keyType)
+		case "/char/WalletJournal.xml.aspx":
+			journalRequests++
+			q := r.URL.Query()
+			if (q.Get("keyID") != "123" || q.Get("vCode") != "abc") && q.Get("accessToken") != "token" {
+				t.Errorf("Key not passed %v", q)
+			}
+			if q.Get("accessType") != "" {
+				t.Errorf("Character call sent accessType %v", q)
+			}
+			fmt.Fprint(w, `<eveapi version="2"><result><rowset name="transactions">
+				<row date="2016-07-01 10:00:00" refID="5" refTypeID="10" amount="100" balance="200" />
+				</rowset></result></eveapi>`)
+		}
+	}))
+	defer srv.Close()
+	c := &EVEAPIClient{httpClient: http.DefaultClient, base: EveURI{XML: srv.URL + "/"}, userAgent: USER_AGENT}
+
+	for _, test := range []struct {
+		keyType     string
+		accessMask  string
+		characterID int64
+		err         error
+	}{
+		{"Corporation", "2097152", 90000001, ErrXMLKeyType},
+		{"Character", "1", 90000001, ErrXMLKeyMask},
+		{"Account", "2097152", 90000002, ErrXMLKeyCharacter},
+		{"Account", "2097152", 90000001, nil},
+	} {
+		keyType, accessMask = test.keyType, test.accessMask
+		key := &XMLAPIKey{KeyID: 123, VCode: "abc"}
+		info, err := c.APIKeyInfoXML(key)
+		if err != nil {
+			t.Fatalf("Error getting key info %v", err)
+		}
+		if !info.Key.Expires.IsZero() || string(info.Key.Type) != test.keyType {
+			t.Errorf("Wrong key info %+v", info.Key)
+		}
+
+		journalRequests = 0
+		j, err := c.CharacterWalletJournalAuthXML(key, test.characterID, 0)
+		if err != test.err {
+			t.Errorf("Expected %v for %+v, got %v", test.err, test, err)
+		}
+		if test.err != nil && journalRequests != 0 {
+			t.Errorf("Journal requested with a key that does not allow it")
+		}
+		if test.err == nil && (len(j.Entries) != 1 || j.Entries[0].RefID != 5) {
+			t.Errorf("Wrong journal %v", j)
+		}
+	}
+
+	// A copy of the key uses the info the client already has.
+	infoRequests = 0
+	key := XMLAPIKey{KeyID: 123, VCode: "abc"}
+	if _, err := c.CharacterWalletJournalAuthXML(&key, 90000001, 0); err != nil {
+		t.Fatalf("Error getting journal with a copied key %v", err)
+	}
+	if infoRequests != 0 {
+		t.Errorf("Key info fetched again for a copied key")
+	}
+
+	// SSO tokens still work through the original signature.
+	journalRequests = 0
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	if j, err := c.CharacterWalletJournalXML(ts, 90000001, 0); err != nil || len(j.Entries) != 1 || journalRequests != 1 {
+		t.Errorf("Wrong journal with a token %v %v", j, err)
+	}
+}